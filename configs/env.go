@@ -3,12 +3,18 @@ package configs
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/trackvision/tv-shared-go/env"
+
+	"tv-pipelines-timken/i18n"
 )
 
 // Config holds all environment configuration
 type Config struct {
+	TenantID          string // tenant this config belongs to (DEFAULT_TENANT_ID for the env config)
+	DisplayName       string // customer name shown in emails (TENANT_DISPLAY_NAME)
 	Port              string
 	APIKey            string // API key for authenticating requests (CMS_API_KEY)
 	CMSBaseURL        string
@@ -21,6 +27,24 @@ type Config struct {
 	EmailSMTPPort     string
 	EmailSMTPUser     string
 	EmailSMTPPassword string
+	EmailLocale       string // language of the tenant's emails (EMAIL_LOCALE)
+
+	// PipelineTimeout bounds a single pipeline run, independent of the HTTP
+	// server timeouts (PIPELINE_TIMEOUT, default 30m)
+	PipelineTimeout time.Duration
+
+	// Renderer pool: headless Chrome browsers kept running for PDF generation
+	// (0 launches a browser per render)
+	RendererPoolSize       int
+	RendererHealthInterval time.Duration
+
+	// Retention (a rule is disabled while its max age is unset)
+	RetentionRunsMaxAge          time.Duration
+	RetentionRunsCollection      string
+	RetentionArtifactsMaxAge     time.Duration
+	RetentionEmailLogsMaxAge     time.Duration
+	RetentionEmailLogsCollection string
+	RetentionArchive             bool // archive records (status=archived) instead of deleting
 
 	// GCP Configuration (for logs viewer)
 	GCPProjectID    string
@@ -40,7 +64,36 @@ func Load() (*Config, error) {
 
 	emailSMTPPassword, _ := env.GetSecret("EMAIL_SMTP_PASSWORD") // optional
 
+	pipelineTimeout, err := getDuration("PIPELINE_TIMEOUT", 30*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	rendererPoolSize, err := getInt("RENDERER_POOL_SIZE", 2)
+	if err != nil {
+		return nil, err
+	}
+	rendererHealthInterval, err := getDuration("RENDERER_HEALTH_INTERVAL", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	retentionRunsMaxAge, err := getDuration("RETENTION_RUNS_MAX_AGE", 0)
+	if err != nil {
+		return nil, err
+	}
+	retentionArtifactsMaxAge, err := getDuration("RETENTION_ARTIFACTS_MAX_AGE", 0)
+	if err != nil {
+		return nil, err
+	}
+	retentionEmailLogsMaxAge, err := getDuration("RETENTION_EMAIL_LOGS_MAX_AGE", 0)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
+		TenantID:          getEnv("DEFAULT_TENANT_ID", "timken"),
+		DisplayName:       getEnv("TENANT_DISPLAY_NAME", "Timken"),
 		Port:              getEnv("PORT", "8080"),
 		APIKey:            apiKey,
 		CMSBaseURL:        os.Getenv("CMS_BASE_URL"),
@@ -53,8 +106,21 @@ func Load() (*Config, error) {
 		EmailSMTPPort:     getEnv("EMAIL_SMTP_PORT", "587"),
 		EmailSMTPUser:     getEnv("EMAIL_SMTP_USER", "resend"),
 		EmailSMTPPassword: emailSMTPPassword,
-		GCPProjectID:      os.Getenv("GCP_PROJECT_ID"),
-		CloudRunService:   os.Getenv("CLOUD_RUN_SERVICE"),
+		EmailLocale:       getEnv("EMAIL_LOCALE", i18n.Default),
+		PipelineTimeout:   pipelineTimeout,
+
+		RendererPoolSize:       rendererPoolSize,
+		RendererHealthInterval: rendererHealthInterval,
+
+		GCPProjectID:    os.Getenv("GCP_PROJECT_ID"),
+		CloudRunService: os.Getenv("CLOUD_RUN_SERVICE"),
+
+		RetentionRunsMaxAge:          retentionRunsMaxAge,
+		RetentionRunsCollection:      os.Getenv("RETENTION_RUNS_COLLECTION"),
+		RetentionArtifactsMaxAge:     retentionArtifactsMaxAge,
+		RetentionEmailLogsMaxAge:     retentionEmailLogsMaxAge,
+		RetentionEmailLogsCollection: os.Getenv("RETENTION_EMAIL_LOGS_COLLECTION"),
+		RetentionArchive:             os.Getenv("RETENTION_ARCHIVE") == "true",
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -78,6 +144,23 @@ func (c *Config) validate() error {
 		}
 	}
 
+	if i18n.Match(c.EmailLocale) != c.EmailLocale {
+		return fmt.Errorf("EMAIL_LOCALE %q is not supported (supported: %v)", c.EmailLocale, i18n.Locales())
+	}
+
+	// Without a folder, artifact retention would purge every file in Directus
+	if c.RetentionArtifactsMaxAge > 0 && c.COCFolderID == "" {
+		return fmt.Errorf("RETENTION_ARTIFACTS_MAX_AGE requires COC_FOLDER_ID")
+	}
+	// No default collection: this service keeps no run records in Directus, and
+	// the obvious candidate (certification) holds the issued certificates
+	if c.RetentionRunsMaxAge > 0 && c.RetentionRunsCollection == "" {
+		return fmt.Errorf("RETENTION_RUNS_MAX_AGE requires RETENTION_RUNS_COLLECTION")
+	}
+	if c.RetentionEmailLogsMaxAge > 0 && c.RetentionEmailLogsCollection == "" {
+		return fmt.Errorf("RETENTION_EMAIL_LOGS_MAX_AGE requires RETENTION_EMAIL_LOGS_COLLECTION")
+	}
+
 	return nil
 }
 
@@ -87,3 +170,33 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid duration %q: %w", key, value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s: duration must be positive, got %q", key, value)
+	}
+	return d, nil
+}
+
+func getInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid integer %q: %w", key, value, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%s: must not be negative, got %q", key, value)
+	}
+	return n, nil
+}
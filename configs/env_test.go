@@ -2,6 +2,7 @@ package configs
 
 import (
 	"testing"
+	"time"
 )
 
 func TestLoad_Success(t *testing.T) {
@@ -23,6 +24,15 @@ func TestLoad_Success(t *testing.T) {
 	if cfg.Port != "8080" {
 		t.Errorf("Port = %q, want default %q", cfg.Port, "8080")
 	}
+	if cfg.EmailLocale != "en" {
+		t.Errorf("EmailLocale = %q, want default %q", cfg.EmailLocale, "en")
+	}
+	if cfg.PipelineTimeout != 30*time.Minute {
+		t.Errorf("PipelineTimeout = %v, want default %v", cfg.PipelineTimeout, 30*time.Minute)
+	}
+	if cfg.RendererPoolSize != 2 {
+		t.Errorf("RendererPoolSize = %d, want default 2", cfg.RendererPoolSize)
+	}
 }
 
 func TestLoad_MissingRequired(t *testing.T) {
@@ -39,6 +49,50 @@ func TestLoad_MissingRequired(t *testing.T) {
 	}
 }
 
+func TestLoad_RetentionArtifactsRequireFolder(t *testing.T) {
+	t.Setenv("CMS_BASE_URL", "https://cms.example.com")
+	t.Setenv("DIRECTUS_CMS_API_KEY", "test-api-key")
+	t.Setenv("COC_VIEWER_BASE_URL", "https://viewer.example.com")
+	t.Setenv("COC_DATA_API_URL", "https://api.example.com/coc")
+	t.Setenv("EMAIL_FROM_ADDRESS", "test@example.com")
+	t.Setenv("COC_FOLDER_ID", "")
+	t.Setenv("RETENTION_ARTIFACTS_MAX_AGE", "2160h")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Load() expected error for artifact retention without COC_FOLDER_ID")
+	}
+}
+
+func TestLoad_RetentionRunsRequireCollection(t *testing.T) {
+	t.Setenv("CMS_BASE_URL", "https://cms.example.com")
+	t.Setenv("DIRECTUS_CMS_API_KEY", "test-api-key")
+	t.Setenv("COC_VIEWER_BASE_URL", "https://viewer.example.com")
+	t.Setenv("COC_DATA_API_URL", "https://api.example.com/coc")
+	t.Setenv("EMAIL_FROM_ADDRESS", "test@example.com")
+	t.Setenv("RETENTION_RUNS_MAX_AGE", "2160h")
+	t.Setenv("RETENTION_RUNS_COLLECTION", "")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Load() expected error for run retention without RETENTION_RUNS_COLLECTION")
+	}
+}
+
+func TestLoad_UnsupportedEmailLocale(t *testing.T) {
+	t.Setenv("CMS_BASE_URL", "https://cms.example.com")
+	t.Setenv("DIRECTUS_CMS_API_KEY", "test-api-key")
+	t.Setenv("COC_VIEWER_BASE_URL", "https://viewer.example.com")
+	t.Setenv("COC_DATA_API_URL", "https://api.example.com/coc")
+	t.Setenv("EMAIL_FROM_ADDRESS", "test@example.com")
+	t.Setenv("EMAIL_LOCALE", "fr")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Load() expected error for unsupported EMAIL_LOCALE")
+	}
+}
+
 func TestGetEnv_Default(t *testing.T) {
 	// Use a unique var name that won't be set
 	got := getEnv("TEST_VAR_DEFINITELY_NOT_SET_12345", "default-value")
@@ -55,3 +109,55 @@ func TestGetEnv_FromEnv(t *testing.T) {
 		t.Errorf("getEnv() = %q, want %q", got, "env-value")
 	}
 }
+
+func TestGetDuration_Default(t *testing.T) {
+	got, err := getDuration("TEST_DURATION_DEFINITELY_NOT_SET_12345", time.Minute)
+	if err != nil {
+		t.Fatalf("getDuration() error = %v", err)
+	}
+	if got != time.Minute {
+		t.Errorf("getDuration() = %v, want %v", got, time.Minute)
+	}
+}
+
+func TestGetDuration_FromEnv(t *testing.T) {
+	t.Setenv("TEST_DURATION_SET", "45m")
+
+	got, err := getDuration("TEST_DURATION_SET", time.Minute)
+	if err != nil {
+		t.Fatalf("getDuration() error = %v", err)
+	}
+	if got != 45*time.Minute {
+		t.Errorf("getDuration() = %v, want %v", got, 45*time.Minute)
+	}
+}
+
+func TestGetDuration_Invalid(t *testing.T) {
+	for _, value := range []string{"soon", "-5m", "0s"} {
+		t.Setenv("TEST_DURATION_INVALID", value)
+
+		if _, err := getDuration("TEST_DURATION_INVALID", time.Minute); err == nil {
+			t.Errorf("getDuration(%q) expected error", value)
+		}
+	}
+}
+
+func TestGetInt(t *testing.T) {
+	t.Setenv("TEST_INT_SET", "0")
+
+	got, err := getInt("TEST_INT_SET", 2)
+	if err != nil {
+		t.Fatalf("getInt() error = %v", err)
+	}
+	if got != 0 {
+		t.Errorf("getInt() = %d, want 0", got)
+	}
+
+	for _, value := range []string{"two", "-1"} {
+		t.Setenv("TEST_INT_INVALID", value)
+
+		if _, err := getInt("TEST_INT_INVALID", 2); err == nil {
+			t.Errorf("getInt(%q) expected error", value)
+		}
+	}
+}
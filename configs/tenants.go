@@ -0,0 +1,171 @@
+package configs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/trackvision/tv-shared-go/env"
+)
+
+var (
+	// ErrUnauthorized is returned when a request's API key matches no tenant
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrTenantForbidden is returned when a tenant API key requests another tenant
+	ErrTenantForbidden = errors.New("api key is not valid for this tenant")
+	// ErrUnknownTenant is returned for a tenant ID that is not configured
+	ErrUnknownTenant = errors.New("unknown tenant")
+)
+
+// tenantOverrides is one entry of TENANTS_CONFIG. Everything else (SMTP,
+// timeouts, retention) is inherited from the default tenant's config.
+type tenantOverrides struct {
+	ID               string `json:"id"`
+	DisplayName      string `json:"display_name"` // required: never inherited from the default tenant
+	APIKey           string `json:"api_key"`
+	CMSBaseURL       string `json:"cms_base_url"`
+	DirectusAPIKey   string `json:"directus_api_key"`
+	COCViewerBaseURL string `json:"coc_viewer_base_url"`
+	COCDataAPIURL    string `json:"coc_data_api_url"`
+	COCFolderID      string `json:"coc_folder_id"`
+	EmailFromAddress string `json:"email_from_address"`
+	EmailLocale      string `json:"email_locale"` // optional; inherited if empty
+}
+
+// Tenants holds the config of every tenant served by this instance
+type Tenants struct {
+	Default *Config
+	byID    map[string]*Config
+	byKey   map[string]*Config // tenant-scoped API keys (excludes the default key)
+}
+
+// LoadTenants builds the tenant set from the default config plus any tenants
+// defined as a JSON array in the TENANTS_CONFIG secret
+func LoadTenants(base *Config) (*Tenants, error) {
+	raw, err := env.GetSecret("TENANTS_CONFIG")
+	if err != nil {
+		raw = "" // optional: single-tenant deployment
+	}
+	return parseTenants(base, raw)
+}
+
+func parseTenants(base *Config, raw string) (*Tenants, error) {
+	t := &Tenants{
+		Default: base,
+		byID:    map[string]*Config{base.TenantID: base},
+		byKey:   make(map[string]*Config),
+	}
+	if raw == "" {
+		return t, nil
+	}
+
+	var overrides []tenantOverrides
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+		return nil, fmt.Errorf("TENANTS_CONFIG: %w", err)
+	}
+
+	for _, o := range overrides {
+		if o.ID == "" {
+			return nil, fmt.Errorf("TENANTS_CONFIG: tenant id is required")
+		}
+		if _, exists := t.byID[o.ID]; exists {
+			return nil, fmt.Errorf("TENANTS_CONFIG: duplicate tenant %q", o.ID)
+		}
+
+		cfg := *base
+		cfg.TenantID = o.ID
+		cfg.DisplayName = o.DisplayName
+		cfg.APIKey = o.APIKey
+		cfg.CMSBaseURL = o.CMSBaseURL
+		cfg.DirectusAPIKey = o.DirectusAPIKey
+		cfg.COCViewerBaseURL = o.COCViewerBaseURL
+		cfg.COCDataAPIURL = o.COCDataAPIURL
+		cfg.COCFolderID = o.COCFolderID
+		cfg.EmailFromAddress = o.EmailFromAddress
+		if o.EmailLocale != "" {
+			cfg.EmailLocale = o.EmailLocale
+		}
+
+		if err := cfg.validate(); err != nil {
+			return nil, fmt.Errorf("TENANTS_CONFIG: tenant %q: %w", o.ID, err)
+		}
+		if cfg.DirectusAPIKey == "" {
+			return nil, fmt.Errorf("TENANTS_CONFIG: tenant %q: directus_api_key is required", o.ID)
+		}
+		// Inheriting the default's name would brand another customer's emails
+		if cfg.DisplayName == "" {
+			return nil, fmt.Errorf("TENANTS_CONFIG: tenant %q: display_name is required", o.ID)
+		}
+
+		if cfg.APIKey != "" {
+			if base.APIKey == "" {
+				return nil, fmt.Errorf("TENANTS_CONFIG: tenant %q: api_key requires CMS_API_KEY to be set", o.ID)
+			}
+			if _, exists := t.byKey[cfg.APIKey]; exists || cfg.APIKey == base.APIKey {
+				return nil, fmt.Errorf("TENANTS_CONFIG: tenant %q: api_key is already in use", o.ID)
+			}
+			t.byKey[cfg.APIKey] = &cfg
+		}
+		t.byID[o.ID] = &cfg
+	}
+
+	return t, nil
+}
+
+// Get returns the config for a tenant ID
+func (t *Tenants) Get(id string) (*Config, bool) {
+	cfg, ok := t.byID[id]
+	return cfg, ok
+}
+
+// List returns all tenant configs sorted by ID
+func (t *Tenants) List() []*Config {
+	list := make([]*Config, 0, len(t.byID))
+	for _, cfg := range t.byID {
+		list = append(list, cfg)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TenantID < list[j].TenantID })
+	return list
+}
+
+// IDs returns all tenant IDs sorted
+func (t *Tenants) IDs() []string {
+	ids := make([]string, 0, len(t.byID))
+	for _, cfg := range t.List() {
+		ids = append(ids, cfg.TenantID)
+	}
+	return ids
+}
+
+// AuthEnabled reports whether requests must carry an API key. Tenant keys
+// require CMS_API_KEY, so the default key alone decides this.
+func (t *Tenants) AuthEnabled() bool {
+	return t.Default.APIKey != ""
+}
+
+// Resolve picks the tenant for a request from its API key and the requested
+// tenant ID (either may be empty). A tenant API key only grants its own
+// tenant; the default API key (or any caller when auth is disabled) may
+// select any tenant and falls back to the default tenant.
+func (t *Tenants) Resolve(apiKey, tenantID string) (*Config, error) {
+	if scoped, ok := t.byKey[apiKey]; ok && apiKey != "" {
+		if tenantID != "" && tenantID != scoped.TenantID {
+			return nil, ErrTenantForbidden
+		}
+		return scoped, nil
+	}
+
+	if t.AuthEnabled() && apiKey != t.Default.APIKey {
+		return nil, ErrUnauthorized
+	}
+
+	if tenantID == "" {
+		return t.Default, nil
+	}
+	cfg, ok := t.byID[tenantID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, tenantID)
+	}
+	return cfg, nil
+}
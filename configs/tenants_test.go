@@ -0,0 +1,181 @@
+package configs
+
+import (
+	"errors"
+	"testing"
+)
+
+func testBaseConfig() *Config {
+	return &Config{
+		TenantID:         "timken",
+		DisplayName:      "Timken",
+		APIKey:           "admin-key",
+		CMSBaseURL:       "https://timken.example.com",
+		DirectusAPIKey:   "timken-directus-key",
+		COCViewerBaseURL: "https://viewer.example.com",
+		COCDataAPIURL:    "https://api.example.com/coc",
+		COCFolderID:      "timken-folder",
+		EmailFromAddress: "noreply@timken.com",
+		EmailSMTPHost:    "smtp.resend.com",
+		EmailLocale:      "en",
+	}
+}
+
+const testTenantsConfig = `[{
+	"id": "acme",
+	"display_name": "Acme Bearings",
+	"api_key": "acme-key",
+	"cms_base_url": "https://acme.example.com",
+	"directus_api_key": "acme-directus-key",
+	"coc_viewer_base_url": "https://viewer.acme.example.com",
+	"coc_data_api_url": "https://api.acme.example.com/coc",
+	"email_from_address": "noreply@acme.com",
+	"email_locale": "de"
+}]`
+
+func TestParseTenants_SingleTenant(t *testing.T) {
+	base := testBaseConfig()
+
+	tenants, err := parseTenants(base, "")
+	if err != nil {
+		t.Fatalf("parseTenants() error = %v", err)
+	}
+
+	ids := tenants.IDs()
+	if len(ids) != 1 || ids[0] != "timken" {
+		t.Errorf("IDs() = %v, want [timken]", ids)
+	}
+	if tenants.Default != base {
+		t.Error("Default should be the base config")
+	}
+}
+
+func TestParseTenants_Isolated(t *testing.T) {
+	tenants, err := parseTenants(testBaseConfig(), testTenantsConfig)
+	if err != nil {
+		t.Fatalf("parseTenants() error = %v", err)
+	}
+
+	acme, ok := tenants.Get("acme")
+	if !ok {
+		t.Fatal("Get(acme) not found")
+	}
+	if acme.CMSBaseURL != "https://acme.example.com" {
+		t.Errorf("CMSBaseURL = %q, want acme URL", acme.CMSBaseURL)
+	}
+	// Folder belongs to the default tenant's Directus and must not be inherited
+	if acme.COCFolderID != "" {
+		t.Errorf("COCFolderID = %q, want empty", acme.COCFolderID)
+	}
+	if acme.DisplayName != "Acme Bearings" {
+		t.Errorf("DisplayName = %q, want Acme Bearings", acme.DisplayName)
+	}
+	if acme.EmailLocale != "de" {
+		t.Errorf("EmailLocale = %q, want de", acme.EmailLocale)
+	}
+	// Shared settings are inherited
+	if acme.EmailSMTPHost != "smtp.resend.com" {
+		t.Errorf("EmailSMTPHost = %q, want inherited smtp.resend.com", acme.EmailSMTPHost)
+	}
+	if tenants.Default.CMSBaseURL != "https://timken.example.com" {
+		t.Error("default tenant config was modified")
+	}
+}
+
+func TestParseTenants_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "bad json", raw: `{`},
+		{name: "missing id", raw: `[{"cms_base_url": "https://acme.example.com"}]`},
+		{name: "duplicate default", raw: `[{"id": "timken"}]`},
+		{name: "missing required", raw: `[{"id": "acme", "directus_api_key": "k"}]`},
+		{name: "missing display name", raw: `[{
+			"id": "acme",
+			"cms_base_url": "https://acme.example.com",
+			"directus_api_key": "k",
+			"coc_viewer_base_url": "https://viewer.acme.example.com",
+			"coc_data_api_url": "https://api.acme.example.com/coc",
+			"email_from_address": "noreply@acme.com"
+		}]`},
+		{name: "reused api key", raw: `[{
+			"id": "acme",
+			"display_name": "Acme Bearings",
+			"api_key": "admin-key",
+			"cms_base_url": "https://acme.example.com",
+			"directus_api_key": "k",
+			"coc_viewer_base_url": "https://viewer.acme.example.com",
+			"coc_data_api_url": "https://api.acme.example.com/coc",
+			"email_from_address": "noreply@acme.com"
+		}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseTenants(testBaseConfig(), tt.raw); err == nil {
+				t.Error("parseTenants() expected error")
+			}
+		})
+	}
+}
+
+func TestTenants_Resolve(t *testing.T) {
+	tenants, err := parseTenants(testBaseConfig(), testTenantsConfig)
+	if err != nil {
+		t.Fatalf("parseTenants() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		tenantID string
+		want     string
+		wantErr  error
+	}{
+		{name: "default key, no tenant", apiKey: "admin-key", want: "timken"},
+		{name: "default key selects tenant", apiKey: "admin-key", tenantID: "acme", want: "acme"},
+		{name: "tenant key", apiKey: "acme-key", want: "acme"},
+		{name: "tenant key, own tenant", apiKey: "acme-key", tenantID: "acme", want: "acme"},
+		{name: "tenant key, other tenant", apiKey: "acme-key", tenantID: "timken", wantErr: ErrTenantForbidden},
+		{name: "no key", wantErr: ErrUnauthorized},
+		{name: "wrong key", apiKey: "nope", wantErr: ErrUnauthorized},
+		{name: "unknown tenant", apiKey: "admin-key", tenantID: "globex", wantErr: ErrUnknownTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := tenants.Resolve(tt.apiKey, tt.tenantID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Resolve() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if cfg.TenantID != tt.want {
+				t.Errorf("Resolve() tenant = %q, want %q", cfg.TenantID, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenants_ResolveAuthDisabled(t *testing.T) {
+	base := testBaseConfig()
+	base.APIKey = ""
+
+	tenants, err := parseTenants(base, "")
+	if err != nil {
+		t.Fatalf("parseTenants() error = %v", err)
+	}
+
+	cfg, err := tenants.Resolve("", "")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if cfg.TenantID != "timken" {
+		t.Errorf("Resolve() tenant = %q, want timken", cfg.TenantID)
+	}
+}
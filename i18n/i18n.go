@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the fallback locale for missing locales and messages
+const Default = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// catalogs maps locale -> message key -> message
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: read locales: %v", err))
+	}
+
+	result := make(map[string]map[string]string, len(files))
+	for _, f := range files {
+		data, err := localesFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: read %s: %v", f.Name(), err))
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: parse %s: %v", f.Name(), err))
+		}
+		result[strings.TrimSuffix(f.Name(), ".json")] = messages
+	}
+
+	if _, ok := result[Default]; !ok {
+		panic("i18n: missing default locale " + Default)
+	}
+	return result
+}
+
+// Locales returns the supported locales, sorted
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match returns the supported locale for a language tag such as "de-DE" or
+// "zh_Hans", or "" if the language is not supported
+func Match(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	lang, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	return ""
+}
+
+// Negotiate picks the best supported locale from an Accept-Language header,
+// falling back to Default
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale := Match(tag)
+		if locale == "" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
+
+// T returns the message for key in locale, falling back to Default and then
+// to the key itself. Args, if any, are applied with fmt.Sprintf.
+func T(locale, key string, args ...any) string {
+	msg, ok := catalogs[locale][key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Messages returns every message for a locale with keys starting with prefix,
+// filled in from Default where the locale has no translation. Used to pass
+// client-side strings to the UI scripts.
+func Messages(locale, prefix string) map[string]string {
+	result := make(map[string]string)
+	for key, msg := range catalogs[Default] {
+		if strings.HasPrefix(key, prefix) {
+			result[key] = msg
+		}
+	}
+	for key, msg := range catalogs[locale] {
+		if strings.HasPrefix(key, prefix) {
+			result[key] = msg
+		}
+	}
+	return result
+}
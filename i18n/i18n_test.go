@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalogs_Complete(t *testing.T) {
+	for _, locale := range Locales() {
+		for key := range catalogs[Default] {
+			if _, ok := catalogs[locale][key]; !ok {
+				t.Errorf("locale %q is missing key %q", locale, key)
+			}
+		}
+		for key := range catalogs[locale] {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("locale %q has key %q not in %q", locale, key, Default)
+			}
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	got := strings.Join(Locales(), ",")
+	if got != "de,en,es,zh" {
+		t.Errorf("Locales() = %q, want de,en,es,zh", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "de", want: "de"},
+		{tag: "de-DE", want: "de"},
+		{tag: "ES-mx", want: "es"},
+		{tag: "zh_Hans", want: "zh"},
+		{tag: "fr", want: ""},
+		{tag: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.tag); got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: "en"},
+		{header: "de-DE,de;q=0.9,en;q=0.8", want: "de"},
+		{header: "fr-FR,fr;q=0.9,es;q=0.5", want: "es"},
+		{header: "en;q=0.5,zh-CN", want: "zh"},
+		{header: "fr", want: "en"},
+		{header: "de;q=0", want: "en"},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("de", "logs.refresh"); got != "Aktualisieren" {
+		t.Errorf("T(de, logs.refresh) = %q, want Aktualisieren", got)
+	}
+	if got := T("fr", "logs.refresh"); got != "Refresh" {
+		t.Errorf("T(fr, logs.refresh) = %q, want fallback Refresh", got)
+	}
+	if got := T("zh", "email.subject", "Acme"); got != "Acme 合格证书" {
+		t.Errorf("T(zh, email.subject, Acme) = %q, want Acme 合格证书", got)
+	}
+	if got := T("de", "no.such.key"); got != "no.such.key" {
+		t.Errorf("T(de, no.such.key) = %q, want key", got)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	got := Messages("es", "job.")
+	if got["job.running"] != "Ejecutando..." {
+		t.Errorf("Messages(es)[job.running] = %q, want Ejecutando...", got["job.running"])
+	}
+	if _, ok := got["logs.refresh"]; ok {
+		t.Error("Messages(es, job.) should not include logs.* keys")
+	}
+}
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -18,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"tv-pipelines-timken/configs"
+	"tv-pipelines-timken/i18n"
 	"tv-pipelines-timken/pipelines"
 	"tv-pipelines-timken/pipelines/coc"
 	"tv-pipelines-timken/tasks"
@@ -28,7 +35,7 @@ import (
 var templatesFS embed.FS
 
 // PipelineFunc is the standard signature for all pipelines
-type PipelineFunc func(ctx context.Context, cms *tasks.DirectusClient, cfg *configs.Config, sscc string) (*types.PipelineResult, error)
+type PipelineFunc func(ctx context.Context, cms *tasks.DirectusClient, renderer *tasks.RendererPool, cfg *configs.Config, sscc string) (*types.PipelineResult, error)
 
 // Pipeline registry - simple map
 var pipelineRegistry = map[string]PipelineFunc{
@@ -51,35 +58,184 @@ type jobInfoResponse struct {
 	Schedule string   `json:"schedule"`
 }
 
-// authMiddleware checks for valid API key in Authorization header or X-API-Key header
-func authMiddleware(apiKey string, next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// If no API key configured, skip auth
-		if apiKey == "" {
-			next(w, r)
+// gzipWriterPool reuses gzip writers across responses
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		gz, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return gz
+	},
+}
+
+// gzipMiddleware compresses responses for clients that send Accept-Encoding: gzip
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		// Check Authorization: Bearer <key>
-		authHeader := r.Header.Get("Authorization")
-		if strings.HasPrefix(authHeader, "Bearer ") {
-			token := strings.TrimPrefix(authHeader, "Bearer ")
-			if token == apiKey {
-				next(w, r)
-				return
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.Close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		// gzip;q=0 explicitly refuses the encoding
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
 			}
 		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the response body. The gzip stream is started
+// lazily on the first write, so handlers can still set headers and status.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+
+	h := g.ResponseWriter.Header()
+	if status != http.StatusNoContent && status != http.StatusNotModified && h.Get("Content-Encoding") == "" {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		g.gz = gzipWriterPool.Get().(*gzip.Writer)
+		g.gz.Reset(g.ResponseWriter)
+	}
+	g.ResponseWriter.WriteHeader(status)
+}
+
+func (g *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !g.wroteHeader {
+		if g.ResponseWriter.Header().Get("Content-Type") == "" {
+			g.ResponseWriter.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.gz == nil {
+		return g.ResponseWriter.Write(b)
+	}
+	return g.gz.Write(b)
+}
+
+// Flush pushes buffered compressed data to the client
+func (g *gzipResponseWriter) Flush() {
+	if g.gz != nil {
+		_ = g.gz.Flush()
+	}
+	_ = http.NewResponseController(g.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (g *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return g.ResponseWriter
+}
+
+// Close finishes the gzip stream and returns the writer to the pool
+func (g *gzipResponseWriter) Close() {
+	if g.gz == nil {
+		return
+	}
+	_ = g.gz.Close()
+	gzipWriterPool.Put(g.gz)
+	g.gz = nil
+}
+
+// tenantCtxKey is the request context key for the resolved tenant config
+type tenantCtxKey struct{}
+
+// resolveTenant resolves the tenant for a request, trying the Authorization:
+// Bearer token first and then X-API-Key. Callers behind Cloud Run IAM send a
+// Google ID token as the bearer and the API key in X-API-Key.
+func resolveTenant(tenants *configs.Tenants, r *http.Request, tenantID string) (*configs.Config, error) {
+	var keys []string
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		keys = append(keys, token)
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" || len(keys) == 0 {
+		keys = append(keys, key)
+	}
+
+	var firstErr error
+	for _, key := range keys {
+		cfg, err := tenants.Resolve(key, tenantID)
+		if err == nil {
+			return cfg, nil
+		}
+		// Prefer a specific error (forbidden, unknown tenant) over unauthorized
+		if firstErr == nil || errors.Is(firstErr, configs.ErrUnauthorized) {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// authMiddleware resolves the tenant for a request from its API key and the
+// optional X-Tenant-ID header or ?tenant= query parameter
+func authMiddleware(tenants *configs.Tenants, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID := r.Header.Get("X-Tenant-ID")
+		if tenantID == "" {
+			tenantID = r.URL.Query().Get("tenant")
+		}
 
-		// Check X-API-Key header
-		if r.Header.Get("X-API-Key") == apiKey {
-			next(w, r)
+		cfg, err := resolveTenant(tenants, r, tenantID)
+		if err != nil {
+			writeTenantError(w, err)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+		next(w, r.WithContext(context.WithValue(r.Context(), tenantCtxKey{}, cfg)))
+	}
+}
+
+// adminMiddleware only admits the default API key, not tenant-scoped keys
+func adminMiddleware(tenants *configs.Tenants, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := resolveTenant(tenants, r, tenants.Default.TenantID); err != nil {
+			writeTenantError(w, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// tenantFromContext returns the tenant config resolved by authMiddleware
+func tenantFromContext(ctx context.Context) *configs.Config {
+	cfg, _ := ctx.Value(tenantCtxKey{}).(*configs.Config)
+	return cfg
+}
+
+// writeTenantError maps a tenant resolution error to an HTTP status
+func writeTenantError(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	switch {
+	case errors.Is(err, configs.ErrTenantForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, configs.ErrUnknownTenant):
+		status = http.StatusNotFound
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 }
 
 func main() {
@@ -89,11 +245,28 @@ func main() {
 		logger.Fatal("failed to load configuration", zap.Error(err))
 	}
 
-	// Create Directus client
-	cms := tasks.NewDirectusClient(cfg)
+	// Load tenants (the env config is the default tenant)
+	tenants, err := configs.LoadTenants(cfg)
+	if err != nil {
+		logger.Fatal("failed to load tenants", zap.Error(err))
+	}
+
+	// Create a Directus client per tenant
+	cmsClients := make(map[string]*tasks.DirectusClient)
+	for _, tenant := range tenants.List() {
+		cmsClients[tenant.TenantID] = tasks.NewDirectusClient(tenant)
+	}
+
+	// Keep a pool of browsers for PDF generation (nil: a browser per render)
+	var renderer *tasks.RendererPool
+	if cfg.RendererPoolSize > 0 {
+		renderer = tasks.NewRendererPool(cfg.RendererPoolSize, cfg.RendererHealthInterval)
+		defer renderer.Close()
+		expvar.Publish("renderer_pool", expvar.Func(func() any { return renderer.Stats() }))
+	}
 
 	// Parse templates
-	tmpl, err := template.ParseFS(templatesFS, "templates/*.html")
+	tmpl, err := template.New("").Funcs(template.FuncMap{"t": i18n.T}).ParseFS(templatesFS, "templates/*.html")
 	if err != nil {
 		logger.Fatal("failed to parse templates", zap.Error(err))
 	}
@@ -106,34 +279,51 @@ func main() {
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
 	})
+	mux.HandleFunc("/health/details", makeHealthDetailsHandler(renderer))
 
 	// API endpoints (auth required)
-	mux.HandleFunc("/jobs", authMiddleware(cfg.APIKey, jobsHandler))
-	mux.HandleFunc("/jobs/", authMiddleware(cfg.APIKey, jobInfoHandler))
-	mux.HandleFunc("/run/coc", authMiddleware(cfg.APIKey, handlePipeline("coc", cms, cfg)))
+	mux.HandleFunc("/jobs", authMiddleware(tenants, jobsHandler))
+	mux.HandleFunc("/jobs/", authMiddleware(tenants, jobInfoHandler))
+	mux.HandleFunc("/run/coc", authMiddleware(tenants, handlePipeline("coc", tenants, cmsClients, renderer)))
 
 	// Logs endpoint (auth required)
-	mux.HandleFunc("/logs", authMiddleware(cfg.APIKey, makeLogsHandler(cfg)))
+	mux.HandleFunc("/logs", authMiddleware(tenants, makeLogsHandler(tenants)))
+
+	// Retention pass over every tenant, triggered by Cloud Scheduler (default API key only)
+	mux.HandleFunc("/tasks/retention", adminMiddleware(tenants, makeRetentionHandler(retentionWorkers(tenants, cmsClients))))
+
+	// Metrics in expvar JSON format (default API key only: covers all tenants)
+	mux.HandleFunc("/debug/vars", adminMiddleware(tenants, expvar.Handler().ServeHTTP))
 
 	// UI endpoints (no auth - for browser access)
 	mux.HandleFunc("/", redirectToUI)
 	mux.HandleFunc("/ui/", makeUIIndexHandler(tmpl))
-	mux.HandleFunc("/ui/jobs/", makeUIJobHandler(tmpl))
-	mux.HandleFunc("/ui/logs", makeUILogsHandler(tmpl, cfg))
+	mux.HandleFunc("/ui/jobs/", makeUIJobHandler(tmpl, tenants))
+	mux.HandleFunc("/ui/logs", makeUILogsHandler(tmpl, cfg, tenants))
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.Port),
-		Handler:      mux,
+		Handler:      gzipMiddleware(mux),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 120 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Background workers run until shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	// Health-check the browsers, restarting any that crash or hang
+	if renderer != nil {
+		go renderer.Run(workerCtx)
+	}
+
 	go func() {
 		logger.Info("starting server",
 			zap.String("port", cfg.Port),
 			zap.Strings("pipelines", getPipelineNames()),
-			zap.Bool("auth_enabled", cfg.APIKey != ""))
+			zap.Strings("tenants", tenants.IDs()),
+			zap.Bool("auth_enabled", tenants.AuthEnabled()))
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Fatal("server failed", zap.Error(err))
 		}
@@ -144,6 +334,7 @@ func main() {
 	<-quit
 
 	logger.Info("shutting down server")
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -153,6 +344,92 @@ func main() {
 	logger.Info("server stopped")
 }
 
+// healthDetailsResponse is the body of GET /health/details
+type healthDetailsResponse struct {
+	Status   string               `json:"status"`
+	Renderer *tasks.RendererStats `json:"renderer,omitempty"` // nil when the pool is disabled
+}
+
+// makeHealthDetailsHandler reports the renderer pool state (GET /health/details).
+// Returns 503 when no browser is available to render PDFs.
+func makeHealthDetailsHandler(renderer *tasks.RendererPool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := healthDetailsResponse{Status: "healthy"}
+		if renderer != nil {
+			stats := renderer.Stats()
+			resp.Status = stats.Status
+			resp.Renderer = &stats
+		}
+
+		status := http.StatusOK
+		if resp.Status == "unhealthy" {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// retentionTimeout bounds a single retention pass over every tenant
+const retentionTimeout = 10 * time.Minute
+
+// retentionResponse is the body of POST /tasks/retention
+type retentionResponse struct {
+	Purged map[string]map[string]int `json:"purged"`           // records purged, by tenant and type
+	Errors map[string]string         `json:"errors,omitempty"` // by tenant
+}
+
+// retentionWorkers returns a retention worker for each tenant with a
+// retention period configured
+func retentionWorkers(tenants *configs.Tenants, cmsClients map[string]*tasks.DirectusClient) map[string]*tasks.RetentionWorker {
+	workers := make(map[string]*tasks.RetentionWorker)
+	for _, tenant := range tenants.List() {
+		if worker := tasks.NewRetentionWorker(cmsClients[tenant.TenantID], tenant); worker != nil {
+			workers[tenant.TenantID] = worker
+		}
+	}
+	return workers
+}
+
+// makeRetentionHandler purges expired records for every tenant (POST
+// /tasks/retention). Returns 500 if any tenant failed, so the scheduler
+// records the failure and retries.
+func makeRetentionHandler(workers map[string]*tasks.RetentionWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Like pipeline runs, a pass outlives the request and the server WriteTimeout
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), retentionTimeout)
+		defer cancel()
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(retentionTimeout + responseWriteGrace))
+
+		resp := retentionResponse{Purged: make(map[string]map[string]int, len(workers))}
+		for tenantID, worker := range workers {
+			purged, err := worker.PurgeOnce(ctx)
+			resp.Purged[tenantID] = purged
+			if err != nil {
+				if resp.Errors == nil {
+					resp.Errors = make(map[string]string)
+				}
+				resp.Errors[tenantID] = err.Error()
+			}
+		}
+
+		status := http.StatusOK
+		if len(resp.Errors) > 0 {
+			status = http.StatusInternalServerError
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
 // jobsHandler returns list of all pipeline names (GET /jobs)
 func jobsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -190,7 +467,10 @@ func jobInfoHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func handlePipeline(name string, cms *tasks.DirectusClient, cfg *configs.Config) http.HandlerFunc {
+// pipelineRuns counts pipeline runs, keyed by tenant/pipeline/outcome
+var pipelineRuns = expvar.NewMap("pipeline_runs_total")
+
+func handlePipeline(name string, tenants *configs.Tenants, cmsClients map[string]*tasks.DirectusClient, renderer *tasks.RendererPool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -214,40 +494,93 @@ func handlePipeline(name string, cms *tasks.DirectusClient, cfg *configs.Config)
 			return
 		}
 
-		// Build context with skip steps if provided
-		ctx := r.Context()
+		// A tenant in the body takes precedence over the header/query tenant
+		cfg := tenantFromContext(r.Context())
+		if req.Tenant != "" && req.Tenant != cfg.TenantID {
+			resolved, err := resolveTenant(tenants, r, req.Tenant)
+			if err != nil {
+				writeTenantError(w, err)
+				return
+			}
+			cfg = resolved
+		}
+		cms := cmsClients[cfg.TenantID]
+
+		// Run on a context detached from the request, bounded by its own deadline,
+		// so a client disconnect cannot abort a run mid-upload. The write deadline
+		// is pushed past the run's deadline so the server WriteTimeout cannot cut
+		// off a long render, and the response still carries the real status.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), cfg.PipelineTimeout)
+		defer cancel()
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(cfg.PipelineTimeout + responseWriteGrace))
+
+		// Add tenant and skip steps to context
+		ctx = context.WithValue(ctx, pipelines.TenantKey, cfg.TenantID)
 		if len(req.SkipSteps) > 0 {
 			ctx = context.WithValue(ctx, pipelines.SkipStepsKey, req.SkipSteps)
 		}
 
 		logger.Info("pipeline started",
+			zap.String("tenant", cfg.TenantID),
 			zap.String("pipeline", name),
 			zap.String("sscc", req.SSCC),
-			zap.Strings("skip_steps", req.SkipSteps))
+			zap.Strings("skip_steps", req.SkipSteps),
+			zap.Duration("timeout", cfg.PipelineTimeout))
 
-		result, err := pipeline(ctx, cms, cfg, req.SSCC)
+		result, err := pipeline(ctx, cms, renderer, cfg, req.SSCC)
 		if err != nil {
-			logger.Error("pipeline failed", zap.String("pipeline", name), zap.Error(err))
+			pipelineRuns.Add(cfg.TenantID+"/"+name+"/failure", 1)
+			logger.Error("pipeline failed",
+				zap.String("tenant", cfg.TenantID),
+				zap.String("pipeline", name),
+				zap.Error(err))
 			writeError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		logger.Info("pipeline complete", zap.String("pipeline", name), zap.Bool("success", result.Success))
+		pipelineRuns.Add(cfg.TenantID+"/"+name+"/"+runOutcome(result), 1)
+		logger.Info("pipeline complete",
+			zap.String("tenant", cfg.TenantID),
+			zap.String("pipeline", name),
+			zap.Bool("success", result.Success),
+			zap.Strings("warnings", result.Warnings))
 
-		w.Header().Set("Content-Type", "application/json")
+		// Warnings (e.g. email failed after upload) still return 200: the
+		// certification exists and retriggering would create a duplicate
+		status := http.StatusOK
 		if !result.Success {
-			w.WriteHeader(http.StatusInternalServerError)
+			status = http.StatusInternalServerError
 		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
 		_ = json.NewEncoder(w).Encode(types.PipelineResponse{
 			Success:         result.Success,
 			CertificationID: result.CertificationID,
 			FileID:          result.FileID,
 			EmailSent:       result.EmailSent,
 			Error:           result.Error,
+			Warnings:        result.Warnings,
+			Steps:           result.Steps,
 		})
 	}
 }
 
+// runOutcome labels a pipeline result for metrics
+func runOutcome(result *types.PipelineResult) string {
+	switch {
+	case !result.Success:
+		return "failure"
+	case len(result.Warnings) > 0:
+		return "success_with_warnings"
+	default:
+		return "success"
+	}
+}
+
+// responseWriteGrace is how long past a run's deadline its response may take
+// to write
+const responseWriteGrace = 10 * time.Second
+
 // redirectToUI redirects root to UI
 func redirectToUI(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path == "/" {
@@ -257,6 +590,14 @@ func redirectToUI(w http.ResponseWriter, r *http.Request) {
 	http.NotFound(w, r)
 }
 
+// requestLocale picks the UI locale from ?lang= or the Accept-Language header
+func requestLocale(r *http.Request) string {
+	if locale := i18n.Match(r.URL.Query().Get("lang")); locale != "" {
+		return locale
+	}
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
+
 // makeUIIndexHandler returns UI index page showing all pipelines
 func makeUIIndexHandler(tmpl *template.Template) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -264,15 +605,18 @@ func makeUIIndexHandler(tmpl *template.Template) http.HandlerFunc {
 			http.NotFound(w, r)
 			return
 		}
+		locale := requestLocale(r)
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Language", locale)
 		_ = tmpl.ExecuteTemplate(w, "index.html", map[string]any{
-			"Jobs": getPipelineNames(),
+			"Locale": locale,
+			"Jobs":   getPipelineNames(),
 		})
 	}
 }
 
 // makeUIJobHandler returns UI page for a specific pipeline
-func makeUIJobHandler(tmpl *template.Template) http.HandlerFunc {
+func makeUIJobHandler(tmpl *template.Template, tenants *configs.Tenants) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		name := strings.TrimPrefix(r.URL.Path, "/ui/jobs/")
 		if name == "" {
@@ -286,10 +630,17 @@ func makeUIJobHandler(tmpl *template.Template) http.HandlerFunc {
 			return
 		}
 
+		locale := requestLocale(r)
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Language", locale)
 		_ = tmpl.ExecuteTemplate(w, "job.html", map[string]any{
-			"Name":  name,
-			"Tasks": steps,
+			"Locale":   locale,
+			"Messages": i18n.Messages(locale, "job."),
+			"Name":     name,
+			"Tasks":    steps,
+			// Tenant IDs are not listed: this page is unauthenticated and
+			// /run/coc checks the tenant against the API key
+			"MultiTenant": len(tenants.IDs()) > 1,
 		})
 	}
 }
@@ -303,21 +654,47 @@ func writeError(w http.ResponseWriter, status int, message string) {
 	})
 }
 
-// logsResponse is the response format for the /logs API
-type logsResponse struct {
-	Runs  []tasks.PipelineRun `json:"runs"`
-	Count int                 `json:"count"`
-	Query map[string]any      `json:"query"`
+// writeLogsResponse streams the /logs response one run at a time instead of
+// marshalling the whole payload into memory first. The output has the shape
+// {"runs": [...], "count": N, "query": {...}}.
+func writeLogsResponse(w io.Writer, runs []tasks.PipelineRun, query map[string]any) error {
+	enc := json.NewEncoder(w)
+
+	if _, err := io.WriteString(w, `{"runs":[`); err != nil {
+		return err
+	}
+	for i, run := range runs {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(run); err != nil {
+			return fmt.Errorf("encode run: %w", err)
+		}
+	}
+	if _, err := fmt.Fprintf(w, `],"count":%d,"query":`, len(runs)); err != nil {
+		return err
+	}
+	if err := enc.Encode(query); err != nil {
+		return fmt.Errorf("encode query: %w", err)
+	}
+	_, err := io.WriteString(w, "}\n")
+	return err
 }
 
 // makeLogsHandler returns logs from GCP Cloud Logging
-func makeLogsHandler(cfg *configs.Config) http.HandlerFunc {
+func makeLogsHandler(tenants *configs.Tenants) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 
+		// Logs are shared infrastructure (same project and service for all
+		// tenants); runs are filtered to the resolved tenant
+		cfg := tenantFromContext(r.Context())
+
 		// Check if logging is configured
 		if cfg.GCPProjectID == "" || cfg.CloudRunService == "" {
 			w.Header().Set("Content-Type", "application/json")
@@ -369,6 +746,8 @@ func makeLogsHandler(cfg *configs.Config) http.HandlerFunc {
 		logs, err := logClient.QueryLogs(ctx, tasks.LogQuery{
 			ProjectID:   cfg.GCPProjectID,
 			ServiceName: cfg.CloudRunService,
+			Tenant:      cfg.TenantID,
+			Untagged:    cfg == tenants.Default,
 			Pipeline:    pipeline,
 			Severity:    severity,
 			Since:       since,
@@ -386,28 +765,35 @@ func makeLogsHandler(cfg *configs.Config) http.HandlerFunc {
 		runs := tasks.GroupByRun(logs, cfg.GCPProjectID, cfg.CloudRunService)
 
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(logsResponse{
-			Runs:  runs,
-			Count: len(runs),
-			Query: map[string]any{
-				"pipeline": pipeline,
-				"severity": severity,
-				"since":    sinceStr,
-				"limit":    limit,
-			},
+		err = writeLogsResponse(w, runs, map[string]any{
+			"tenant":   cfg.TenantID,
+			"pipeline": pipeline,
+			"severity": severity,
+			"since":    sinceStr,
+			"limit":    limit,
 		})
+		if err != nil {
+			logger.Warn("failed to write logs response", zap.Error(err))
+		}
 	}
 }
 
 // makeUILogsHandler returns the logs viewer UI page
-func makeUILogsHandler(tmpl *template.Template, cfg *configs.Config) http.HandlerFunc {
+func makeUILogsHandler(tmpl *template.Template, cfg *configs.Config, tenants *configs.Tenants) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		locale := requestLocale(r)
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Language", locale)
 		_ = tmpl.ExecuteTemplate(w, "logs.html", map[string]any{
+			"Locale":      locale,
+			"Messages":    i18n.Messages(locale, "logs."),
 			"Configured":  cfg.GCPProjectID != "" && cfg.CloudRunService != "",
 			"ProjectID":   cfg.GCPProjectID,
 			"ServiceName": cfg.CloudRunService,
 			"Pipelines":   getPipelineNames(),
+			// Tenant IDs are not listed: this page is unauthenticated and
+			// /logs checks the tenant against the API key
+			"MultiTenant": len(tenants.IDs()) > 1,
 		})
 	}
 }
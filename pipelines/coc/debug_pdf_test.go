@@ -32,7 +32,7 @@ func TestDebug_GeneratePDF(t *testing.T) {
 
 	t.Logf("Generating PDF for SSCC: %s", sscc)
 
-	pdfData, filename, err := tasks.GeneratePDF(ctx, cfg, sscc)
+	pdfData, filename, err := tasks.GeneratePDF(ctx, nil, cfg, sscc)
 	if err != nil {
 		t.Fatalf("GeneratePDF failed: %v", err)
 	}
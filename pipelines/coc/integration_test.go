@@ -43,7 +43,7 @@ func TestIntegration_COCPipeline(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	result, err := Run(ctx, cms, cfg, sscc)
+	result, err := Run(ctx, cms, nil, cfg, sscc)
 	if err != nil {
 		t.Fatalf("pipeline returned error: %v", err)
 	}
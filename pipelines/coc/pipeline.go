@@ -23,9 +23,9 @@ var Steps = []string{
 	"send_email",
 }
 
-// Run executes the COC pipeline
-func Run(ctx context.Context, cms *tasks.DirectusClient, cfg *configs.Config, sscc string) (*types.PipelineResult, error) {
-	logger := zap.L().With(zap.String("sscc", sscc))
+// Run executes the COC pipeline. renderer may be nil to launch a browser per run.
+func Run(ctx context.Context, cms *tasks.DirectusClient, renderer *tasks.RendererPool, cfg *configs.Config, sscc string) (*types.PipelineResult, error) {
+	logger := zap.L().With(zap.String("tenant", cfg.TenantID), zap.String("sscc", sscc))
 	logger.Info("coc pipeline started")
 
 	// Shared state via closures
@@ -43,7 +43,7 @@ func Run(ctx context.Context, cms *tasks.DirectusClient, cfg *configs.Config, ss
 
 	// Task: generate_pdf (no deps)
 	flow.AddTask("generate_pdf", func() error {
-		data, filename, err := tasks.GeneratePDF(ctx, cfg, sscc)
+		data, filename, err := tasks.GeneratePDF(ctx, renderer, cfg, sscc)
 		if err != nil {
 			return fmt.Errorf("generate PDF: %w", err)
 		}
@@ -104,8 +104,10 @@ func Run(ctx context.Context, cms *tasks.DirectusClient, cfg *configs.Config, ss
 		return nil
 	}, "create_certification", "generate_pdf")
 
-	// Task: send_email (depends on upload_pdf)
-	flow.AddTask("send_email", func() error {
+	// Task: send_email (depends on upload_pdf). Optional: by this point the
+	// certification exists, so an email failure is reported as a warning
+	// rather than failing the run and prompting a duplicate retrigger.
+	flow.AddOptionalTask("send_email", func() error {
 		sent, err := tasks.SendEmail(ctx, cfg, cocData, pdfData, pdfFilename)
 		if err != nil {
 			return fmt.Errorf("send email: %w", err)
@@ -117,24 +119,53 @@ func Run(ctx context.Context, cms *tasks.DirectusClient, cfg *configs.Config, ss
 	// Run the flow
 	if err := flow.Run(ctx); err != nil {
 		return &types.PipelineResult{
-			Success: false,
-			Error:   err.Error(),
+			Success:         false,
+			CertificationID: certificationID,
+			FileID:          fileID,
+			Error:           err.Error(),
+			Steps:           flow.Steps(),
 		}, nil
 	}
 
+	steps := flow.Steps()
+	warnings := emailWarnings(steps, emailSent)
+
 	logger.Info("coc pipeline complete",
 		zap.String("certification_id", certificationID),
 		zap.String("file_id", fileID),
-		zap.Bool("email_sent", emailSent))
+		zap.Bool("email_sent", emailSent),
+		zap.Strings("warnings", warnings))
 
 	return &types.PipelineResult{
 		Success:         true,
 		CertificationID: certificationID,
 		FileID:          fileID,
 		EmailSent:       emailSent,
+		Warnings:        warnings,
+		Steps:           steps,
 	}, nil
 }
 
+// emailWarnings explains why no email went out for a successful run
+func emailWarnings(steps []types.StepStatus, emailSent bool) []string {
+	for _, step := range steps {
+		if step.Name != "send_email" {
+			continue
+		}
+		switch step.Status {
+		case types.StepFailed:
+			return []string{"email not sent: " + step.Error}
+		case types.StepSkipped:
+			return []string{"email not sent: send_email step skipped"}
+		case types.StepCompleted:
+			if !emailSent {
+				return []string{"email not sent: send_coc_emails is not enabled for this shipment"}
+			}
+		}
+	}
+	return nil
+}
+
 // prepareRecord transforms COC data into a certification record
 func prepareRecord(cocData *types.COCData) (*types.CertificationRecord, error) {
 	if cocData == nil || len(cocData.Items) == 0 {
@@ -1,6 +1,7 @@
 package coc
 
 import (
+	"reflect"
 	"testing"
 
 	"tv-pipelines-timken/types"
@@ -110,3 +111,44 @@ func TestPrepareRecord(t *testing.T) {
 		}
 	})
 }
+
+func TestEmailWarnings(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    types.StepStatus
+		emailSent bool
+		want      []string
+	}{
+		{
+			name:      "sent",
+			status:    types.StepStatus{Name: "send_email", Status: types.StepCompleted},
+			emailSent: true,
+			want:      nil,
+		},
+		{
+			name:   "not enabled",
+			status: types.StepStatus{Name: "send_email", Status: types.StepCompleted},
+			want:   []string{"email not sent: send_coc_emails is not enabled for this shipment"},
+		},
+		{
+			name:   "skipped",
+			status: types.StepStatus{Name: "send_email", Status: types.StepSkipped},
+			want:   []string{"email not sent: send_email step skipped"},
+		},
+		{
+			name:   "failed",
+			status: types.StepStatus{Name: "send_email", Status: types.StepFailed, Error: "smtp timeout"},
+			want:   []string{"email not sent: smtp timeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []types.StepStatus{{Name: "upload_pdf", Status: types.StepCompleted}, tt.status}
+			got := emailWarnings(steps, tt.emailSent)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("emailWarnings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
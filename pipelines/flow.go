@@ -8,6 +8,8 @@ import (
 	"github.com/fieldryand/goflow/v2"
 	"github.com/trackvision/tv-shared-go/logger"
 	"go.uber.org/zap"
+
+	"tv-pipelines-timken/types"
 )
 
 // ContextKey is a type for context keys used by the pipelines package.
@@ -16,12 +18,18 @@ type ContextKey string
 // SkipStepsKey is the context key for skip steps.
 const SkipStepsKey ContextKey = "skip_steps"
 
+// TenantKey is the context key for the tenant ID a pipeline runs for.
+const TenantKey ContextKey = "tenant"
+
 // Flow provides a fluent API for building and running pipelines.
 type Flow struct {
 	job       *goflow.Job
 	taskOrder []string
 	tasks     map[string]*goflow.Task
+	optional  map[string]bool
+	steps     []types.StepStatus
 	name      string
+	tenant    string
 }
 
 // NewFlow creates a new pipeline flow.
@@ -32,8 +40,9 @@ func NewFlow(name string) *Flow {
 			Schedule: "@manual",
 			Active:   true,
 		},
-		tasks: make(map[string]*goflow.Task),
-		name:  name,
+		tasks:    make(map[string]*goflow.Task),
+		optional: make(map[string]bool),
+		name:     name,
 	}
 }
 
@@ -61,6 +70,20 @@ func (f *Flow) AddTask(name string, fn func() error, deps ...string) *Flow {
 	return f
 }
 
+// AddOptionalTask adds a task whose failure is recorded in Steps but does not
+// stop the flow. Use it for side effects such as notifications that must not
+// mask work already committed by earlier steps.
+func (f *Flow) AddOptionalTask(name string, fn func() error, deps ...string) *Flow {
+	f.AddTask(name, fn, deps...)
+	f.optional[name] = true
+	return f
+}
+
+// Steps returns the outcome of each step from the last Run, in execution order.
+func (f *Flow) Steps() []types.StepStatus {
+	return append([]types.StepStatus{}, f.steps...)
+}
+
 // Run executes the pipeline synchronously with comprehensive logging.
 func (f *Flow) Run(ctx context.Context) error {
 	startTime := time.Now()
@@ -68,22 +91,27 @@ func (f *Flow) Run(ctx context.Context) error {
 	// Build task name list for logging
 	taskNames := append([]string{}, f.taskOrder...)
 
-	// Get skip steps from context
+	// Get skip steps and tenant from context
 	skipSteps := getSkipStepsFromContext(ctx)
+	f.tenant, _ = ctx.Value(TenantKey).(string)
 
 	logger.Info("flow started",
 		zap.String("pipeline", f.name),
+		zap.String("tenant", f.tenant),
 		zap.Int("task_count", len(f.taskOrder)),
 		zap.Strings("steps", taskNames),
 		zap.Int("skip_count", len(skipSteps)))
 
 	completedCount := 0
 	skippedCount := 0
+	failedCount := 0
+	f.steps = make([]types.StepStatus, 0, len(f.taskOrder))
 
-	for _, name := range f.taskOrder {
+	for i, name := range f.taskOrder {
 		task := f.tasks[name]
 
 		if err := ctx.Err(); err != nil {
+			f.markNotRun(f.taskOrder[i:])
 			return fmt.Errorf("cancelled before %s: %w", name, err)
 		}
 
@@ -91,22 +119,33 @@ func (f *Flow) Run(ctx context.Context) error {
 		if skipSteps[name] {
 			logger.Info("step skipped",
 				zap.String("pipeline", f.name),
+				zap.String("tenant", f.tenant),
 				zap.String("step", name))
+			f.steps = append(f.steps, types.StepStatus{Name: name, Status: types.StepSkipped})
 			skippedCount++
 			continue
 		}
 
 		if err := f.runTaskWithLogging(ctx, task); err != nil {
+			f.steps = append(f.steps, types.StepStatus{Name: name, Status: types.StepFailed, Error: err.Error()})
+			if f.optional[name] {
+				failedCount++
+				continue
+			}
+			f.markNotRun(f.taskOrder[i+1:])
 			return err
 		}
+		f.steps = append(f.steps, types.StepStatus{Name: name, Status: types.StepCompleted})
 		completedCount++
 	}
 
 	logger.Info("flow completed",
 		zap.String("pipeline", f.name),
+		zap.String("tenant", f.tenant),
 		zap.Duration("duration", time.Since(startTime)),
 		zap.Int("steps_completed", completedCount),
-		zap.Int("steps_skipped", skippedCount))
+		zap.Int("steps_skipped", skippedCount),
+		zap.Int("steps_failed_optional", failedCount))
 
 	return nil
 }
@@ -117,11 +156,22 @@ func (f *Flow) runTaskWithLogging(ctx context.Context, t *goflow.Task) error {
 
 	logger.Info("step started",
 		zap.String("pipeline", f.name),
+		zap.String("tenant", f.tenant),
 		zap.String("step", t.Name))
 
 	if err := runWithRetry(ctx, t); err != nil {
+		if f.optional[t.Name] {
+			logger.Warn("optional step failed",
+				zap.String("pipeline", f.name),
+				zap.String("tenant", f.tenant),
+				zap.String("step", t.Name),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(taskStart)))
+			return err
+		}
 		logger.Error("step failed",
 			zap.String("pipeline", f.name),
+			zap.String("tenant", f.tenant),
 			zap.String("step", t.Name),
 			zap.Error(err),
 			zap.Duration("duration", time.Since(taskStart)))
@@ -130,12 +180,20 @@ func (f *Flow) runTaskWithLogging(ctx context.Context, t *goflow.Task) error {
 
 	logger.Info("step completed",
 		zap.String("pipeline", f.name),
+		zap.String("tenant", f.tenant),
 		zap.String("step", t.Name),
 		zap.Duration("duration", time.Since(taskStart)))
 
 	return nil
 }
 
+// markNotRun records the given steps as not run (after a fatal failure)
+func (f *Flow) markNotRun(names []string) {
+	for _, name := range names {
+		f.steps = append(f.steps, types.StepStatus{Name: name, Status: types.StepNotRun})
+	}
+}
+
 // Job returns the underlying goflow Job for visualization.
 func (f *Flow) Job() *goflow.Job {
 	return f.job
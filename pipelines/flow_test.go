@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"tv-pipelines-timken/types"
 )
 
 func init() {
@@ -95,11 +97,71 @@ func TestFlow_TaskError(t *testing.T) {
 	flow.AddTask("failing", func() error {
 		return expectedErr
 	})
+	flow.AddTask("after", func() error {
+		t.Error("task after a failed step should not run")
+		return nil
+	}, "failing")
 
 	err := flow.Run(context.Background())
 	if err == nil {
 		t.Fatal("Run() expected error")
 	}
+
+	steps := flow.Steps()
+	if len(steps) != 2 || steps[0].Status != types.StepFailed || steps[1].Status != types.StepNotRun {
+		t.Errorf("Steps() = %+v, want [failing: failed, after: not_run]", steps)
+	}
+}
+
+func TestFlow_OptionalTaskFailure(t *testing.T) {
+	executed := false
+
+	flow := NewFlow("test")
+	flow.AddOptionalTask("notify", func() error {
+		return errors.New("smtp unavailable")
+	})
+	flow.AddTask("after", func() error {
+		executed = true
+		return nil
+	}, "notify")
+
+	err := flow.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v, want nil for optional task failure", err)
+	}
+	if !executed {
+		t.Error("task after a failed optional step was not executed")
+	}
+
+	steps := flow.Steps()
+	if len(steps) != 2 {
+		t.Fatalf("Steps() count = %d, want 2", len(steps))
+	}
+	if steps[0].Status != types.StepFailed || steps[0].Error == "" {
+		t.Errorf("notify step = %+v, want failed with error", steps[0])
+	}
+	if steps[1].Status != types.StepCompleted {
+		t.Errorf("after step status = %q, want %q", steps[1].Status, types.StepCompleted)
+	}
+}
+
+func TestFlow_SkippedStepStatus(t *testing.T) {
+	flow := NewFlow("test")
+	flow.AddTask("first", func() error { return nil })
+	flow.AddTask("second", func() error {
+		t.Error("skipped task should not run")
+		return nil
+	}, "first")
+
+	ctx := context.WithValue(context.Background(), SkipStepsKey, []string{"second"})
+	if err := flow.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	steps := flow.Steps()
+	if len(steps) != 2 || steps[0].Status != types.StepCompleted || steps[1].Status != types.StepSkipped {
+		t.Errorf("Steps() = %+v, want [first: completed, second: skipped]", steps)
+	}
 }
 
 func TestFlow_ContextCancellation(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"time"
 
 	"tv-pipelines-timken/configs"
@@ -160,6 +161,103 @@ func (c *DirectusClient) UploadFile(ctx context.Context, params UploadFileParams
 	return result.Data.ID, nil
 }
 
+// ListItemIDs returns the IDs of up to limit items in a collection matching
+// filter, e.g. filter.Set("filter[date_created][_lt]", cutoff)
+func (c *DirectusClient) ListItemIDs(ctx context.Context, collection string, filter url.Values, limit int) ([]string, error) {
+	return c.listIDs(ctx, fmt.Sprintf("%s/items/%s", c.baseURL, collection), filter, limit)
+}
+
+// ListFileIDs returns the IDs of up to limit files matching filter
+func (c *DirectusClient) ListFileIDs(ctx context.Context, filter url.Values, limit int) ([]string, error) {
+	return c.listIDs(ctx, fmt.Sprintf("%s/files", c.baseURL), filter, limit)
+}
+
+// DeleteItems deletes items from a collection by ID
+func (c *DirectusClient) DeleteItems(ctx context.Context, collection string, ids []string) error {
+	return c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("%s/items/%s", c.baseURL, collection), ids)
+}
+
+// DeleteFiles deletes files (and their stored content) by ID
+func (c *DirectusClient) DeleteFiles(ctx context.Context, ids []string) error {
+	return c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("%s/files", c.baseURL), ids)
+}
+
+// UpdateItems applies the same updates to several items in a collection
+func (c *DirectusClient) UpdateItems(ctx context.Context, collection string, ids []string, updates map[string]interface{}) error {
+	return c.sendJSON(ctx, http.MethodPatch, fmt.Sprintf("%s/items/%s", c.baseURL, collection), map[string]interface{}{
+		"keys": ids,
+		"data": updates,
+	})
+}
+
+func (c *DirectusClient) listIDs(ctx context.Context, endpoint string, filter url.Values, limit int) ([]string, error) {
+	query := url.Values{}
+	for key, values := range filter {
+		query[key] = values
+	}
+	query.Set("fields", "id")
+	query.Set("limit", fmt.Sprintf("%d", limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list items: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("directus returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result types.DirectusResponse[[]struct {
+		ID string `json:"id"`
+	}]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	ids := make([]string, 0, len(result.Data))
+	for _, item := range result.Data {
+		ids = append(ids, item.ID)
+	}
+	return ids, nil
+}
+
+func (c *DirectusClient) sendJSON(ctx context.Context, method, endpoint string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("directus returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
 func (c *DirectusClient) setHeaders(req *http.Request) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"tv-pipelines-timken/configs"
@@ -145,3 +146,70 @@ func TestDirectusClient_PostItem_Error(t *testing.T) {
 		t.Error("PostItem() expected error for 500 response")
 	}
 }
+
+func TestDirectusClient_ListItemIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method = %q, want GET", r.Method)
+		}
+		if r.URL.Path != "/items/test-collection" {
+			t.Errorf("Path = %q, want /items/test-collection", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("filter[date_created][_lt]") != "2024-01-01T00:00:00Z" {
+			t.Errorf("filter = %q, want date_created cutoff", q.Get("filter[date_created][_lt]"))
+		}
+		if q.Get("fields") != "id" || q.Get("limit") != "50" {
+			t.Errorf("fields/limit = %q/%q, want id/50", q.Get("fields"), q.Get("limit"))
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"a"},{"id":"b"}]}`))
+	}))
+	defer server.Close()
+
+	client := &DirectusClient{
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+		httpClient: http.DefaultClient,
+	}
+
+	filter := url.Values{}
+	filter.Set("filter[date_created][_lt]", "2024-01-01T00:00:00Z")
+	ids, err := client.ListItemIDs(context.Background(), "test-collection", filter, 50)
+	if err != nil {
+		t.Fatalf("ListItemIDs() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("ListItemIDs() = %v, want [a b]", ids)
+	}
+}
+
+func TestDirectusClient_DeleteItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method = %q, want DELETE", r.Method)
+		}
+		if r.URL.Path != "/items/test-collection" {
+			t.Errorf("Path = %q, want /items/test-collection", r.URL.Path)
+		}
+		var ids []string
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if len(ids) != 2 {
+			t.Errorf("ids = %v, want 2 ids", ids)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &DirectusClient{
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+		httpClient: http.DefaultClient,
+	}
+
+	err := client.DeleteItems(context.Background(), "test-collection", []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("DeleteItems() error = %v", err)
+	}
+}
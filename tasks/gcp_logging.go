@@ -15,6 +15,7 @@ import (
 type LogEntry struct {
 	Timestamp time.Time `json:"timestamp"`
 	Severity  string    `json:"severity"`
+	Tenant    string    `json:"tenant,omitempty"`
 	Pipeline  string    `json:"pipeline,omitempty"`
 	Step      string    `json:"step,omitempty"`
 	Message   string    `json:"message"`
@@ -24,6 +25,7 @@ type LogEntry struct {
 
 // PipelineRun represents a single pipeline execution with its steps
 type PipelineRun struct {
+	Tenant    string       `json:"tenant,omitempty"`
 	Pipeline  string       `json:"pipeline"`
 	StartTime time.Time    `json:"start_time"`
 	EndTime   time.Time    `json:"end_time,omitempty"`
@@ -38,7 +40,7 @@ type PipelineRun struct {
 type StepResult struct {
 	Name     string  `json:"name"`
 	Duration float64 `json:"duration,omitempty"`
-	Status   string  `json:"status"` // "completed", "failed", "running"
+	Status   string  `json:"status"` // "completed", "failed", "skipped", "running"
 	Error    string  `json:"error,omitempty"`
 }
 
@@ -46,6 +48,8 @@ type StepResult struct {
 type LogQuery struct {
 	ProjectID   string
 	ServiceName string
+	Tenant      string        // optional filter by tenant ID
+	Untagged    bool          // with Tenant, also include entries logged before tenants existed
 	Pipeline    string        // optional filter by pipeline name
 	Severity    string        // optional filter (INFO, WARNING, ERROR)
 	Since       time.Duration // how far back to look (default: 1 hour)
@@ -99,6 +103,15 @@ func (c *LogClient) QueryLogs(ctx context.Context, q LogQuery) ([]LogEntry, erro
 		filter += fmt.Sprintf(` AND severity>="%s"`, q.Severity)
 	}
 
+	// Add tenant filter if specified
+	if q.Tenant != "" {
+		tenantFilter := fmt.Sprintf(`jsonPayload.tenant="%s"`, q.Tenant)
+		if q.Untagged {
+			tenantFilter = fmt.Sprintf(`(%s OR NOT jsonPayload.tenant:*)`, tenantFilter)
+		}
+		filter += " AND " + tenantFilter
+	}
+
 	// Add pipeline filter if specified
 	if q.Pipeline != "" {
 		filter += fmt.Sprintf(` AND jsonPayload.pipeline="%s"`, q.Pipeline)
@@ -133,6 +146,9 @@ func (c *LogClient) QueryLogs(ctx context.Context, q LogQuery) ([]LogEntry, erro
 			if msg := fields["msg"]; msg != nil {
 				logEntry.Message = msg.GetStringValue()
 			}
+			if tenant := fields["tenant"]; tenant != nil {
+				logEntry.Tenant = tenant.GetStringValue()
+			}
 			if pipeline := fields["pipeline"]; pipeline != nil {
 				logEntry.Pipeline = pipeline.GetStringValue()
 			}
@@ -150,6 +166,9 @@ func (c *LogClient) QueryLogs(ctx context.Context, q LogQuery) ([]LogEntry, erro
 			if msg, ok := p["msg"].(string); ok {
 				logEntry.Message = msg
 			}
+			if tenant, ok := p["tenant"].(string); ok {
+				logEntry.Tenant = tenant
+			}
 			if pipeline, ok := p["pipeline"].(string); ok {
 				logEntry.Pipeline = pipeline
 			}
@@ -211,7 +230,7 @@ func GroupByRun(entries []LogEntry, projectID, serviceName string) []PipelineRun
 		}
 	}
 
-	runMap := make(map[string]*PipelineRun) // key: pipeline + start time bucket
+	runMap := make(map[string]*PipelineRun) // key: tenant + pipeline + start time bucket
 
 	for _, entry := range sorted {
 		if entry.Pipeline == "" {
@@ -221,20 +240,21 @@ func GroupByRun(entries []LogEntry, projectID, serviceName string) []PipelineRun
 		// Find or create run based on "pipeline started" message
 		if entry.Message == "pipeline started" || entry.Message == "flow started" {
 			run := &PipelineRun{
+				Tenant:    entry.Tenant,
 				Pipeline:  entry.Pipeline,
 				StartTime: entry.Timestamp,
 				Success:   true, // assume success until we see failure
 				Steps:     []StepResult{},
 			}
-			key := fmt.Sprintf("%s-%d", entry.Pipeline, entry.Timestamp.Unix())
+			key := fmt.Sprintf("%s-%s-%d", entry.Tenant, entry.Pipeline, entry.Timestamp.Unix())
 			runMap[key] = run
 			continue
 		}
 
-		// Find the most recent run for this pipeline
+		// Find the most recent run for this tenant and pipeline
 		var currentRun *PipelineRun
 		for _, r := range runMap {
-			if r.Pipeline == entry.Pipeline && entry.Timestamp.After(r.StartTime) {
+			if r.Tenant == entry.Tenant && r.Pipeline == entry.Pipeline && entry.Timestamp.After(r.StartTime) {
 				if currentRun == nil || r.StartTime.After(currentRun.StartTime) {
 					currentRun = r
 				}
@@ -244,12 +264,13 @@ func GroupByRun(entries []LogEntry, projectID, serviceName string) []PipelineRun
 		if currentRun == nil {
 			// Create implicit run if we see steps without a start
 			currentRun = &PipelineRun{
+				Tenant:    entry.Tenant,
 				Pipeline:  entry.Pipeline,
 				StartTime: entry.Timestamp,
 				Success:   true,
 				Steps:     []StepResult{},
 			}
-			key := fmt.Sprintf("%s-%d", entry.Pipeline, entry.Timestamp.Unix())
+			key := fmt.Sprintf("%s-%s-%d", entry.Tenant, entry.Pipeline, entry.Timestamp.Unix())
 			runMap[key] = currentRun
 		}
 
@@ -268,6 +289,18 @@ func GroupByRun(entries []LogEntry, projectID, serviceName string) []PipelineRun
 			})
 			currentRun.Success = false
 			currentRun.Error = entry.Error
+		} else if entry.Message == "optional step failed" && entry.Step != "" {
+			// Optional steps (e.g. send_email) fail without failing the run
+			currentRun.Steps = append(currentRun.Steps, StepResult{
+				Name:   entry.Step,
+				Status: "failed",
+				Error:  entry.Error,
+			})
+		} else if entry.Message == "step skipped" && entry.Step != "" {
+			currentRun.Steps = append(currentRun.Steps, StepResult{
+				Name:   entry.Step,
+				Status: "skipped",
+			})
 		} else if entry.Message == "flow completed" {
 			currentRun.Duration = entry.Duration
 			currentRun.EndTime = entry.Timestamp
@@ -28,8 +28,9 @@ func (s silentLogger) Printf(format string, args ...interface{}) {
 	log.Printf(format, args...)
 }
 
-// GeneratePDF generates a PDF from the COC viewer webpage using chromedp
-func GeneratePDF(ctx context.Context, cfg *configs.Config, sscc string) ([]byte, string, error) {
+// GeneratePDF generates a PDF from the COC viewer webpage using chromedp.
+// Renders on renderer if set, else launches a browser for this render only.
+func GeneratePDF(ctx context.Context, renderer *RendererPool, cfg *configs.Config, sscc string) ([]byte, string, error) {
 	viewerURL, err := url.Parse(cfg.COCViewerBaseURL)
 	if err != nil {
 		return nil, "", fmt.Errorf("invalid COC viewer URL: %w", err)
@@ -39,44 +40,44 @@ func GeneratePDF(ctx context.Context, cfg *configs.Config, sscc string) ([]byte,
 	q.Set("sscc", sscc)
 	viewerURL.RawQuery = q.Encode()
 
-	// Configure Chrome options for Cloud Run (headless-shell)
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", "new"),
-		chromedp.Flag("disable-gpu", true),
-		chromedp.NoSandbox,
-	)
-
-	allocCtx, cancel := chromedp.NewExecAllocator(ctx, opts...)
-	defer cancel()
-
-	// Use silent logger to suppress unmarshal warnings
-	chromeCtx, cancel := chromedp.NewContext(allocCtx, chromedp.WithErrorf(silentLogger{}.Printf))
-	defer cancel()
-
 	var pdfData []byte
 
 	logger.Info("navigating to COC viewer",
 		zap.String("sscc", sscc),
 		zap.String("url", viewerURL.String()))
 
-	err = chromedp.Run(chromeCtx,
-		chromedp.Navigate(viewerURL.String()),
-		// Wait for the certificate content to render
-		chromedp.WaitVisible(`#certificate`, chromedp.ByQuery),
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			var err error
-			pdfData, _, err = page.PrintToPDF().
-				WithPrintBackground(true).
-				WithPaperWidth(8.27).   // A4 width in inches
-				WithPaperHeight(11.69). // A4 height in inches
-				WithMarginTop(0.39).    // ~10mm in inches
-				WithMarginBottom(0.39).
-				WithMarginLeft(0.39).
-				WithMarginRight(0.39).
-				Do(ctx)
-			return err
-		}),
-	)
+	render := func(browserCtx context.Context) error {
+		// Each render gets its own tab, closed when done or when ctx is cancelled
+		tabCtx, cancel := chromedp.NewContext(browserCtx)
+		defer cancel()
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+
+		return chromedp.Run(tabCtx,
+			chromedp.Navigate(viewerURL.String()),
+			// Wait for the certificate content to render
+			chromedp.WaitVisible(`#certificate`, chromedp.ByQuery),
+			chromedp.ActionFunc(func(ctx context.Context) error {
+				var err error
+				pdfData, _, err = page.PrintToPDF().
+					WithPrintBackground(true).
+					WithPaperWidth(8.27).   // A4 width in inches
+					WithPaperHeight(11.69). // A4 height in inches
+					WithMarginTop(0.39).    // ~10mm in inches
+					WithMarginBottom(0.39).
+					WithMarginLeft(0.39).
+					WithMarginRight(0.39).
+					Do(ctx)
+				return err
+			}),
+		)
+	}
+
+	if renderer != nil {
+		err = renderer.Render(ctx, render)
+	} else {
+		err = renderOnce(ctx, render)
+	}
 	if err != nil {
 		return nil, "", fmt.Errorf("generate PDF: %w", err)
 	}
@@ -93,3 +94,13 @@ func GeneratePDF(ctx context.Context, cfg *configs.Config, sscc string) ([]byte,
 
 	return pdfData, filename, nil
 }
+
+// renderOnce launches a browser for a single render and shuts it down after
+func renderOnce(ctx context.Context, render func(browserCtx context.Context) error) error {
+	browserCtx, cancel, err := launchBrowser(ctx)
+	if err != nil {
+		return fmt.Errorf("start browser: %w", err)
+	}
+	defer cancel()
+	return render(browserCtx)
+}
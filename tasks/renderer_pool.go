@@ -0,0 +1,357 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"go.uber.org/zap"
+)
+
+const (
+	// rendererPingTimeout bounds a single browser health check
+	rendererPingTimeout = 10 * time.Second
+	// rendererRecentRenders is how many renders the average render time covers
+	rendererRecentRenders = 50
+)
+
+// ErrRendererPoolClosed is returned when rendering on a closed pool
+var ErrRendererPoolClosed = errors.New("renderer pool is closed")
+
+// RendererStats is a snapshot of the renderer pool
+type RendererStats struct {
+	Status       string  `json:"status"` // healthy, degraded or unhealthy
+	Size         int     `json:"size"`
+	Busy         int     `json:"busy"`
+	Waiting      int     `json:"waiting"`        // renders queued because every browser is rendering
+	Unhealthy    int     `json:"unhealthy"`      // browsers down and awaiting restart
+	Crashes      int64   `json:"crashes_total"`  // browsers found down or unresponsive
+	Restarts     int64   `json:"restarts_total"` // browsers relaunched after a crash
+	Renders      int64   `json:"renders_total"`
+	RenderErrors int64   `json:"render_errors_total"`
+	AvgRenderMs  float64 `json:"avg_render_ms"` // over the most recent renders
+}
+
+// rendererSlot holds one pooled browser
+type rendererSlot struct {
+	id      int
+	ctx     context.Context // chromedp browser context; nil while the browser is down
+	cancel  context.CancelFunc
+	started bool // a browser has been launched before, so the next launch is a restart
+}
+
+// RendererPool keeps a fixed number of headless Chrome browsers running,
+// hands them out for renders and restarts any that become unhealthy
+type RendererPool struct {
+	size     int
+	interval time.Duration
+	slots    chan *rendererSlot
+
+	// base is the parent of all browsers; cancelled by Close
+	base  context.Context
+	close context.CancelFunc
+
+	// launch and ping are replaced in tests
+	launch func(ctx context.Context) (context.Context, context.CancelFunc, error)
+	ping   func(ctx context.Context) error
+
+	mu        sync.Mutex
+	busy      int
+	waiting   int // renders blocked in acquire
+	checking  int // idle browsers held by a health check
+	unhealthy int
+	crashes   int64
+	restarts  int64
+	renders   int64
+	errors    int64
+	recent    []time.Duration // ring buffer of render durations
+	next      int
+}
+
+// NewRendererPool creates a pool of size browsers, health-checked every
+// interval once Run is called. Browsers are launched on first use.
+func NewRendererPool(size int, interval time.Duration) *RendererPool {
+	base, cancel := context.WithCancel(context.Background())
+	p := &RendererPool{
+		size:      size,
+		interval:  interval,
+		slots:     make(chan *rendererSlot, size),
+		base:      base,
+		close:     cancel,
+		launch:    launchBrowser,
+		ping:      pingBrowser,
+		unhealthy: size,
+	}
+	for i := 0; i < size; i++ {
+		p.slots <- &rendererSlot{id: i}
+	}
+	return p
+}
+
+// Run launches every browser, then health-checks idle browsers every
+// interval until ctx is cancelled
+func (p *RendererPool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.CheckOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce pings every idle browser and restarts those that are down or
+// unresponsive. Busy browsers are checked after their render instead.
+func (p *RendererPool) CheckOnce(ctx context.Context) {
+	// Take the idle slots up front so each is checked exactly once, even if
+	// renders return slots to the channel meanwhile
+	var idle []*rendererSlot
+drain:
+	for len(idle) < p.size {
+		select {
+		case slot := <-p.slots:
+			p.mu.Lock()
+			p.checking++
+			p.mu.Unlock()
+			idle = append(idle, slot)
+		default:
+			break drain
+		}
+	}
+
+	// Ping concurrently and hand each slot back as soon as it is checked, so
+	// a hung browser does not hold up the healthy ones
+	var wg sync.WaitGroup
+	for _, slot := range idle {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ctx.Err() == nil {
+				p.ensureHealthy(slot)
+			}
+			p.mu.Lock()
+			p.checking--
+			p.mu.Unlock()
+			p.slots <- slot
+		}()
+	}
+	wg.Wait()
+}
+
+// Render runs fn with the browser context of a pooled browser, waiting for
+// one to become free. fn should open its own tab with chromedp.NewContext.
+func (p *RendererPool) Render(ctx context.Context, fn func(browserCtx context.Context) error) error {
+	slot, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer p.release(slot)
+
+	if slot.ctx == nil {
+		if err := p.restart(slot); err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	err = fn(slot.ctx)
+	p.record(time.Since(start), err)
+
+	// A failed render may be the page's fault; only restart if the browser is down
+	if err != nil && ctx.Err() == nil {
+		p.ensureHealthy(slot)
+	}
+	return err
+}
+
+// Stats returns a snapshot of the pool state
+func (p *RendererPool) Stats() RendererStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Renders blocked only because a health check holds an idle browser are
+	// not queued for capacity, so they do not count as waiting
+	waiting := max(p.waiting-p.checking, 0)
+
+	stats := RendererStats{
+		Status:       "healthy",
+		Size:         p.size,
+		Busy:         p.busy,
+		Waiting:      waiting,
+		Unhealthy:    p.unhealthy,
+		Crashes:      p.crashes,
+		Restarts:     p.restarts,
+		Renders:      p.renders,
+		RenderErrors: p.errors,
+	}
+	switch {
+	case p.unhealthy >= p.size:
+		stats.Status = "unhealthy"
+	case p.unhealthy > 0 || waiting > 0:
+		stats.Status = "degraded"
+	}
+
+	if len(p.recent) > 0 {
+		var total time.Duration
+		for _, d := range p.recent {
+			total += d
+		}
+		stats.AvgRenderMs = float64(total) / float64(len(p.recent)) / float64(time.Millisecond)
+	}
+	return stats
+}
+
+// Close shuts down every browser. Renders in progress fail.
+func (p *RendererPool) Close() {
+	p.close()
+}
+
+func (p *RendererPool) acquire(ctx context.Context) (*rendererSlot, error) {
+	p.mu.Lock()
+	p.waiting++
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.waiting--
+		p.mu.Unlock()
+	}()
+
+	select {
+	case slot := <-p.slots:
+		p.mu.Lock()
+		p.busy++
+		p.mu.Unlock()
+		return slot, nil
+	case <-p.base.Done():
+		return nil, ErrRendererPoolClosed
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for renderer: %w", ctx.Err())
+	}
+}
+
+func (p *RendererPool) release(slot *rendererSlot) {
+	p.mu.Lock()
+	p.busy--
+	p.mu.Unlock()
+	p.slots <- slot
+}
+
+func (p *RendererPool) record(d time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.renders++
+	if err != nil {
+		p.errors++
+	}
+	if len(p.recent) < rendererRecentRenders {
+		p.recent = append(p.recent, d)
+		return
+	}
+	p.recent[p.next] = d
+	p.next = (p.next + 1) % rendererRecentRenders
+}
+
+// ensureHealthy restarts the slot's browser if it is down or fails a ping.
+// The caller must own the slot.
+func (p *RendererPool) ensureHealthy(slot *rendererSlot) {
+	if p.base.Err() != nil {
+		return
+	}
+
+	if slot.ctx != nil {
+		err := slot.ctx.Err()
+		if err == nil {
+			pingCtx, cancel := context.WithTimeout(slot.ctx, rendererPingTimeout)
+			err = p.ping(pingCtx)
+			cancel()
+		}
+		if err == nil {
+			return
+		}
+
+		zap.L().Warn("renderer browser unhealthy",
+			zap.String("task", "renderer"),
+			zap.Int("browser", slot.id),
+			zap.Error(err))
+		p.stop(slot)
+	}
+
+	_ = p.restart(slot)
+}
+
+// restart launches a browser into an empty slot. The caller must own the slot.
+func (p *RendererPool) restart(slot *rendererSlot) error {
+	if p.base.Err() != nil {
+		return ErrRendererPoolClosed
+	}
+
+	browserCtx, cancel, err := p.launch(p.base)
+	if err != nil {
+		zap.L().Error("renderer browser failed to start",
+			zap.String("task", "renderer"),
+			zap.Int("browser", slot.id),
+			zap.Error(err))
+		return fmt.Errorf("start browser: %w", err)
+	}
+
+	slot.ctx, slot.cancel = browserCtx, cancel
+	p.mu.Lock()
+	p.unhealthy--
+	if slot.started {
+		p.restarts++
+	}
+	p.mu.Unlock()
+	slot.started = true
+	return nil
+}
+
+// stop shuts down a crashed browser. The caller must own the slot.
+func (p *RendererPool) stop(slot *rendererSlot) {
+	slot.cancel()
+	slot.ctx, slot.cancel = nil, nil
+
+	p.mu.Lock()
+	p.unhealthy++
+	p.crashes++
+	p.mu.Unlock()
+}
+
+// launchBrowser starts a headless Chrome configured for Cloud Run (headless-shell)
+func launchBrowser(parent context.Context) (context.Context, context.CancelFunc, error) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", "new"),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.NoSandbox,
+	)
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(parent, opts...)
+	// Use silent logger to suppress unmarshal warnings
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx, chromedp.WithErrorf(silentLogger{}.Printf))
+	cancel := func() {
+		cancelBrowser()
+		cancelAlloc()
+	}
+
+	// Running no actions starts the browser
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return browserCtx, cancel, nil
+}
+
+// pingBrowser checks that the browser's first tab still evaluates JavaScript
+func pingBrowser(ctx context.Context) error {
+	var n int
+	return chromedp.Run(ctx, chromedp.Evaluate(`1 + 1`, &n))
+}
@@ -0,0 +1,288 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeBrowserKey struct{}
+
+// fakeBrowser stands in for a Chrome instance in pool tests
+type fakeBrowser struct {
+	mu   sync.Mutex
+	hung bool
+}
+
+func (b *fakeBrowser) setHung() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hung = true
+}
+
+// newTestPool returns a pool whose launches are recorded instead of starting Chrome
+func newTestPool(size int) (*RendererPool, *[]*fakeBrowser) {
+	var mu sync.Mutex
+	launched := &[]*fakeBrowser{}
+
+	p := NewRendererPool(size, time.Hour)
+	p.launch = func(parent context.Context) (context.Context, context.CancelFunc, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		b := &fakeBrowser{}
+		*launched = append(*launched, b)
+		ctx, cancel := context.WithCancel(context.WithValue(parent, fakeBrowserKey{}, b))
+		return ctx, cancel, nil
+	}
+	p.ping = func(ctx context.Context) error {
+		b := ctx.Value(fakeBrowserKey{}).(*fakeBrowser)
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.hung {
+			return errors.New("browser not responding")
+		}
+		return nil
+	}
+	return p, launched
+}
+
+func TestRendererPool_Render(t *testing.T) {
+	p, launched := newTestPool(2)
+	defer p.Close()
+
+	if got := p.Stats().Status; got != "unhealthy" {
+		t.Errorf("Status before launch = %q, want unhealthy", got)
+	}
+
+	p.CheckOnce(context.Background())
+	if len(*launched) != 2 {
+		t.Fatalf("launched = %d, want 2", len(*launched))
+	}
+
+	for i := 0; i < 3; i++ {
+		err := p.Render(context.Background(), func(browserCtx context.Context) error {
+			if browserCtx.Value(fakeBrowserKey{}) == nil {
+				t.Error("Render() fn should receive a pooled browser context")
+			}
+			if busy := p.Stats().Busy; busy != 1 {
+				t.Errorf("Busy during render = %d, want 1", busy)
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+	}
+
+	stats := p.Stats()
+	if stats.Status != "healthy" || stats.Size != 2 || stats.Busy != 0 || stats.Unhealthy != 0 {
+		t.Errorf("Stats() = %+v, want healthy idle pool of 2", stats)
+	}
+	if stats.Renders != 3 || stats.RenderErrors != 0 {
+		t.Errorf("Renders = %d, errors = %d, want 3, 0", stats.Renders, stats.RenderErrors)
+	}
+	if stats.AvgRenderMs < 1 {
+		t.Errorf("AvgRenderMs = %v, want >= 1", stats.AvgRenderMs)
+	}
+	if len(*launched) != 2 || stats.Restarts != 0 {
+		t.Errorf("launched = %d, restarts = %d, want browsers reused", len(*launched), stats.Restarts)
+	}
+}
+
+func TestRendererPool_RestartsCrashedBrowser(t *testing.T) {
+	p, launched := newTestPool(1)
+	defer p.Close()
+
+	// A page error on a healthy browser keeps the browser
+	renderErr := errors.New("waiting for #certificate: timeout")
+	err := p.Render(context.Background(), func(context.Context) error { return renderErr })
+	if !errors.Is(err, renderErr) {
+		t.Fatalf("Render() error = %v, want %v", err, renderErr)
+	}
+	if len(*launched) != 1 {
+		t.Fatalf("launched = %d, want 1 (healthy browser kept)", len(*launched))
+	}
+
+	// A render error from a hung browser restarts it
+	err = p.Render(context.Background(), func(context.Context) error {
+		(*launched)[0].setHung()
+		return errors.New("websocket closed")
+	})
+	if err == nil {
+		t.Fatal("Render() expected error")
+	}
+	if len(*launched) != 2 {
+		t.Fatalf("launched = %d, want 2 (hung browser restarted)", len(*launched))
+	}
+
+	stats := p.Stats()
+	if stats.Crashes != 1 || stats.Restarts != 1 || stats.Unhealthy != 0 {
+		t.Errorf("Stats() = %+v, want 1 crash, 1 restart, 0 unhealthy", stats)
+	}
+	if stats.Renders != 2 || stats.RenderErrors != 2 {
+		t.Errorf("Renders = %d, errors = %d, want 2, 2", stats.Renders, stats.RenderErrors)
+	}
+}
+
+func TestRendererPool_CheckOnce(t *testing.T) {
+	p, launched := newTestPool(2)
+	defer p.Close()
+
+	p.CheckOnce(context.Background())
+	(*launched)[1].setHung()
+	p.CheckOnce(context.Background())
+
+	if len(*launched) != 3 {
+		t.Fatalf("launched = %d, want 3 (one hung browser replaced)", len(*launched))
+	}
+	if stats := p.Stats(); stats.Crashes != 1 || stats.Restarts != 1 || stats.Status != "healthy" {
+		t.Errorf("Stats() = %+v, want 1 crash, 1 restart, healthy", stats)
+	}
+}
+
+func TestRendererPool_LaunchFailure(t *testing.T) {
+	p, _ := newTestPool(1)
+	defer p.Close()
+	p.launch = func(context.Context) (context.Context, context.CancelFunc, error) {
+		return nil, nil, errors.New("chrome not found")
+	}
+
+	called := false
+	err := p.Render(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("Render() error = %v, called = %v, want launch error before render", err, called)
+	}
+	if got := p.Stats().Status; got != "unhealthy" {
+		t.Errorf("Status = %q, want unhealthy", got)
+	}
+}
+
+func TestRendererPool_WaitCancelled(t *testing.T) {
+	p, _ := newTestPool(1)
+	defer p.Close()
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_ = p.Render(context.Background(), func(context.Context) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := p.Render(ctx, func(context.Context) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Render() error = %v, want deadline exceeded while pool is busy", err)
+	}
+	close(done)
+}
+
+func TestRendererPool_Closed(t *testing.T) {
+	p, _ := newTestPool(1)
+	p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := p.Render(ctx, func(context.Context) error { return nil })
+	if !errors.Is(err, ErrRendererPoolClosed) {
+		t.Errorf("Render() error = %v, want ErrRendererPoolClosed", err)
+	}
+}
+
+func TestRendererPool_CheckOnceNotDegraded(t *testing.T) {
+	p, launched := newTestPool(2)
+	defer p.Close()
+	p.CheckOnce(context.Background()) // launch both browsers
+
+	var mu sync.Mutex
+	pinged := map[*fakeBrowser]int{}
+	pinging := make(chan struct{}, 2)
+	release := make(chan struct{})
+	p.ping = func(ctx context.Context) error {
+		mu.Lock()
+		pinged[ctx.Value(fakeBrowserKey{}).(*fakeBrowser)]++
+		mu.Unlock()
+		pinging <- struct{}{}
+		<-release
+		return nil
+	}
+
+	checked := make(chan struct{})
+	go func() {
+		p.CheckOnce(context.Background())
+		close(checked)
+	}()
+	<-pinging
+	<-pinging
+
+	// A render arriving during the check blocks, but the pool is not short of capacity
+	rendered := make(chan error)
+	go func() {
+		rendered <- p.Render(context.Background(), func(context.Context) error { return nil })
+	}()
+	for deadline := time.Now().Add(time.Second); ; {
+		p.mu.Lock()
+		waiting := p.waiting
+		p.mu.Unlock()
+		if waiting == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("render did not start waiting")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if stats := p.Stats(); stats.Status != "healthy" || stats.Waiting != 0 {
+		t.Errorf("Stats() during health check = %+v, want healthy with 0 waiting", stats)
+	}
+
+	close(release)
+	<-checked
+	if err := <-rendered; err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, b := range *launched {
+		if pinged[b] != 1 {
+			t.Errorf("browser pinged %d times, want 1", pinged[b])
+		}
+	}
+}
+
+func TestRendererPool_WaitingForCapacity(t *testing.T) {
+	p, _ := newTestPool(1)
+	defer p.Close()
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_ = p.Render(context.Background(), func(context.Context) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+
+	go func() { _ = p.Render(context.Background(), func(context.Context) error { return nil }) }()
+	for deadline := time.Now().Add(time.Second); p.Stats().Waiting != 1; {
+		if time.Now().After(deadline) {
+			t.Fatal("Stats().Waiting never reached 1")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := p.Stats().Status; got != "degraded" {
+		t.Errorf("Status = %q, want degraded while renders queue for capacity", got)
+	}
+	close(done)
+}
@@ -0,0 +1,196 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+
+	"tv-pipelines-timken/configs"
+)
+
+const (
+	// retentionBatchSize is how many records are listed and purged per request
+	retentionBatchSize = 100
+	// retentionMaxBatches bounds the work done for one rule in a single pass
+	retentionMaxBatches = 100
+)
+
+// Retention metrics, published on /debug/vars
+var (
+	retentionPurged  = expvar.NewMap("retention_purged_total") // records purged, by tenant/type
+	retentionErrors  = expvar.NewMap("retention_errors_total") // failed passes, by tenant/type
+	retentionLastRun = expvar.NewMap("retention_last_run")     // RFC 3339 time of the last pass, by tenant
+)
+
+// RetentionRule describes how long one type of record is kept
+type RetentionRule struct {
+	Type       string        // metrics label: "runs", "artifacts", "email_logs"
+	Collection string        // Directus collection; empty for file artifacts
+	FolderID   string        // Directus folder holding file artifacts
+	MaxAge     time.Duration // records older than this are purged
+	Archive    bool          // set status=archived instead of deleting (collections only)
+}
+
+// RetentionRules returns the retention rules enabled in the config
+func RetentionRules(cfg *configs.Config) []RetentionRule {
+	var rules []RetentionRule
+	if cfg.RetentionRunsMaxAge > 0 {
+		rules = append(rules, RetentionRule{
+			Type:       "runs",
+			Collection: cfg.RetentionRunsCollection,
+			MaxAge:     cfg.RetentionRunsMaxAge,
+			Archive:    cfg.RetentionArchive,
+		})
+	}
+	if cfg.RetentionArtifactsMaxAge > 0 {
+		rules = append(rules, RetentionRule{
+			Type:     "artifacts",
+			FolderID: cfg.COCFolderID,
+			MaxAge:   cfg.RetentionArtifactsMaxAge,
+		})
+	}
+	if cfg.RetentionEmailLogsMaxAge > 0 {
+		rules = append(rules, RetentionRule{
+			Type:       "email_logs",
+			Collection: cfg.RetentionEmailLogsCollection,
+			MaxAge:     cfg.RetentionEmailLogsMaxAge,
+			Archive:    cfg.RetentionArchive,
+		})
+	}
+	return rules
+}
+
+// RetentionWorker purges records older than their retention period. Passes
+// are triggered externally (POST /tasks/retention from Cloud Scheduler), so
+// only one instance purges at a time.
+type RetentionWorker struct {
+	tenant string
+	cms    *DirectusClient
+	rules  []RetentionRule
+	now    func() time.Time
+}
+
+// NewRetentionWorker creates a retention worker. Returns nil if no rules are enabled.
+func NewRetentionWorker(cms *DirectusClient, cfg *configs.Config) *RetentionWorker {
+	rules := RetentionRules(cfg)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	for _, rule := range rules {
+		if cfg.RetentionArchive && rule.Collection == "" {
+			zap.L().Warn("RETENTION_ARCHIVE does not apply to files: expired artifacts will be deleted",
+				zap.String("task", "retention"),
+				zap.String("tenant", cfg.TenantID),
+				zap.String("folder", rule.FolderID),
+				zap.Duration("max_age", rule.MaxAge))
+		}
+	}
+	return &RetentionWorker{
+		tenant: cfg.TenantID,
+		cms:    cms,
+		rules:  rules,
+		now:    time.Now,
+	}
+}
+
+// PurgeOnce applies every rule once. Returns the number of records purged by
+// type; a failed rule does not stop the others and its error is joined into err.
+func (w *RetentionWorker) PurgeOnce(ctx context.Context) (map[string]int, error) {
+	logger := zap.L().With(zap.String("task", "retention"), zap.String("tenant", w.tenant))
+	purged := make(map[string]int, len(w.rules))
+	var errs []error
+
+	for _, rule := range w.rules {
+		n, err := w.purge(ctx, rule)
+		purged[rule.Type] = n
+		label := w.tenant + "/" + rule.Type
+		retentionPurged.Add(label, int64(n))
+		if err != nil {
+			retentionErrors.Add(label, 1)
+			logger.Error("retention failed",
+				zap.String("type", rule.Type),
+				zap.Int("purged", n),
+				zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		logger.Info("retention complete",
+			zap.String("type", rule.Type),
+			zap.Duration("max_age", rule.MaxAge),
+			zap.Bool("archive", rule.Archive && rule.Collection != ""),
+			zap.Int("purged", n))
+	}
+
+	lastRun := new(expvar.String)
+	lastRun.Set(w.now().UTC().Format(time.RFC3339))
+	retentionLastRun.Set(w.tenant, lastRun)
+	return purged, errors.Join(errs...)
+}
+
+// purge removes records for a single rule in batches
+func (w *RetentionWorker) purge(ctx context.Context, rule RetentionRule) (int, error) {
+	cutoff := w.now().Add(-rule.MaxAge).UTC().Format(time.RFC3339)
+	filter := url.Values{}
+
+	if rule.Collection == "" {
+		filter.Set("filter[folder][_eq]", rule.FolderID)
+		filter.Set("filter[uploaded_on][_lt]", cutoff)
+	} else {
+		filter.Set("filter[date_created][_lt]", cutoff)
+		// Archived records still match the date filter, so skip them. _neq
+		// alone would also skip records with no status (NULL in SQL).
+		if rule.Archive {
+			filter.Set("filter[_or][0][status][_neq]", "archived")
+			filter.Set("filter[_or][1][status][_null]", "true")
+		}
+	}
+
+	total := 0
+	for batch := 0; batch < retentionMaxBatches; batch++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		ids, err := w.list(ctx, rule, filter)
+		if err != nil {
+			return total, fmt.Errorf("list %s: %w", rule.Type, err)
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		if err := w.remove(ctx, rule, ids); err != nil {
+			return total, fmt.Errorf("purge %s: %w", rule.Type, err)
+		}
+		total += len(ids)
+
+		if len(ids) < retentionBatchSize {
+			return total, nil
+		}
+	}
+	return total, nil
+}
+
+func (w *RetentionWorker) list(ctx context.Context, rule RetentionRule, filter url.Values) ([]string, error) {
+	if rule.Collection == "" {
+		return w.cms.ListFileIDs(ctx, filter, retentionBatchSize)
+	}
+	return w.cms.ListItemIDs(ctx, rule.Collection, filter, retentionBatchSize)
+}
+
+func (w *RetentionWorker) remove(ctx context.Context, rule RetentionRule, ids []string) error {
+	switch {
+	case rule.Collection == "":
+		return w.cms.DeleteFiles(ctx, ids)
+	case rule.Archive:
+		return w.cms.UpdateItems(ctx, rule.Collection, ids, map[string]interface{}{"status": "archived"})
+	default:
+		return w.cms.DeleteItems(ctx, rule.Collection, ids)
+	}
+}
@@ -0,0 +1,289 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"tv-pipelines-timken/configs"
+)
+
+func TestRetentionRules(t *testing.T) {
+	cfg := &configs.Config{
+		COCFolderID:              "folder-123",
+		RetentionRunsMaxAge:      90 * 24 * time.Hour,
+		RetentionRunsCollection:  "pipeline_runs",
+		RetentionArtifactsMaxAge: 30 * 24 * time.Hour,
+		RetentionArchive:         true,
+	}
+
+	rules := RetentionRules(cfg)
+	if len(rules) != 2 {
+		t.Fatalf("RetentionRules() count = %d, want 2 (email logs disabled)", len(rules))
+	}
+	if rules[0].Type != "runs" || rules[0].Collection != "pipeline_runs" || !rules[0].Archive {
+		t.Errorf("runs rule = %+v, want pipeline_runs collection archived", rules[0])
+	}
+	if rules[1].Type != "artifacts" || rules[1].FolderID != "folder-123" {
+		t.Errorf("artifacts rule = %+v, want folder-123", rules[1])
+	}
+}
+
+func TestNewRetentionWorker_Disabled(t *testing.T) {
+	if w := NewRetentionWorker(nil, &configs.Config{}); w != nil {
+		t.Error("NewRetentionWorker() expected nil when no rules are enabled")
+	}
+}
+
+func TestRetentionWorker_PurgeOnce(t *testing.T) {
+	remaining := retentionBatchSize + 20 // forces a second batch
+	var deleted, archived int
+	var fileFilter string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/items/pipeline_runs":
+			if got := r.URL.Query().Get("filter[date_created][_lt]"); got != "2024-03-31T00:00:00Z" {
+				t.Errorf("cutoff = %q, want 2024-03-31T00:00:00Z", got)
+			}
+			n := min(remaining, retentionBatchSize)
+			items := make([]map[string]string, n)
+			for i := range items {
+				items[i] = map[string]string{"id": fmt.Sprintf("cert-%d", i)}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": items})
+		case r.Method == http.MethodDelete && r.URL.Path == "/items/pipeline_runs":
+			var ids []string
+			_ = json.NewDecoder(r.Body).Decode(&ids)
+			deleted += len(ids)
+			remaining -= len(ids)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/items/email_log":
+			if r.URL.Query().Get("filter[_or][0][status][_neq]") != "archived" {
+				t.Error("archive rule should exclude already archived records")
+			}
+			if archived > 0 {
+				_, _ = w.Write([]byte(`{"data":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"log-1"},{"id":"log-2"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/items/email_log":
+			var body struct {
+				Keys []string          `json:"keys"`
+				Data map[string]string `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Data["status"] != "archived" {
+				t.Errorf("archive data = %v, want status=archived", body.Data)
+			}
+			archived += len(body.Keys)
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			fileFilter = r.URL.Query().Get("filter[folder][_eq]")
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	worker := &RetentionWorker{
+		tenant: "timken",
+		cms: &DirectusClient{
+			baseURL:    server.URL,
+			apiKey:     "test-key",
+			httpClient: http.DefaultClient,
+		},
+		rules: []RetentionRule{
+			{Type: "runs", Collection: "pipeline_runs", MaxAge: 30 * 24 * time.Hour},
+			{Type: "artifacts", FolderID: "folder-123", MaxAge: 30 * 24 * time.Hour},
+			{Type: "email_logs", Collection: "email_log", MaxAge: 7 * 24 * time.Hour, Archive: true},
+		},
+		now: func() time.Time { return time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC) },
+	}
+
+	before := retentionPurged.Get("timken/runs")
+	purged, err := worker.PurgeOnce(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeOnce() error = %v", err)
+	}
+
+	if purged["runs"] != retentionBatchSize+20 || deleted != retentionBatchSize+20 {
+		t.Errorf("runs purged = %d (deleted %d), want %d", purged["runs"], deleted, retentionBatchSize+20)
+	}
+	if purged["email_logs"] != 2 || archived != 2 {
+		t.Errorf("email_logs purged = %d (archived %d), want 2", purged["email_logs"], archived)
+	}
+	if purged["artifacts"] != 0 {
+		t.Errorf("artifacts purged = %d, want 0", purged["artifacts"])
+	}
+	if fileFilter != "folder-123" {
+		t.Errorf("files folder filter = %q, want folder-123", fileFilter)
+	}
+
+	var beforeCount int64
+	if before != nil {
+		beforeCount = before.(*expvar.Int).Value()
+	}
+	if got := retentionPurged.Get("timken/runs").(*expvar.Int).Value() - beforeCount; got != int64(retentionBatchSize+20) {
+		t.Errorf("retention_purged_total[timken/runs] increased by %d, want %d", got, retentionBatchSize+20)
+	}
+	if got := retentionLastRun.Get("timken"); got == nil || got.(*expvar.String).Value() != "2024-04-30T00:00:00Z" {
+		t.Errorf("retention_last_run[timken] = %v, want 2024-04-30T00:00:00Z", got)
+	}
+}
+
+func TestRetentionWorker_PurgeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal error"))
+	}))
+	defer server.Close()
+
+	worker := &RetentionWorker{
+		cms: &DirectusClient{
+			baseURL:    server.URL,
+			apiKey:     "test-key",
+			httpClient: http.DefaultClient,
+		},
+		rules: []RetentionRule{{Type: "runs", Collection: "pipeline_runs", MaxAge: time.Hour}},
+		now:   time.Now,
+	}
+
+	_, err := worker.purge(context.Background(), worker.rules[0])
+	if err == nil {
+		t.Error("purge() expected error for 500 response")
+	}
+}
+
+// fakeRecord is a Directus item with a nullable status
+type fakeRecord struct {
+	created string
+	status  *string // nil is SQL NULL
+}
+
+// matchCondition applies one Directus filter operator with SQL semantics:
+// comparisons against NULL are never true
+func matchCondition(value *string, op, arg string) bool {
+	switch op {
+	case "_null":
+		return (value == nil) == (arg == "true")
+	case "_neq":
+		return value != nil && *value != arg
+	case "_eq":
+		return value != nil && *value == arg
+	case "_lt":
+		return value != nil && *value < arg
+	}
+	return false
+}
+
+// matchFilter evaluates filter[field][op] and filter[_or][i][field][op]
+// query parameters against a record
+func matchFilter(query url.Values, rec fakeRecord) bool {
+	field := func(name string) *string {
+		if name == "date_created" {
+			return &rec.created
+		}
+		return rec.status
+	}
+
+	orGroups := map[string]bool{}
+	for key, values := range query {
+		path, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(path, "]"), "][")
+		if parts[0] == "_or" {
+			// parts: _or, i, field, op
+			orGroups["_or"] = orGroups["_or"] || matchCondition(field(parts[2]), parts[3], values[0])
+			continue
+		}
+		if !matchCondition(field(parts[0]), parts[1], values[0]) {
+			return false
+		}
+	}
+	if matched, ok := orGroups["_or"]; ok && !matched {
+		return false
+	}
+	return true
+}
+
+func TestRetentionWorker_ArchiveIncludesNullStatus(t *testing.T) {
+	published, archived := "published", "archived"
+	var mu sync.Mutex
+	records := map[string]*fakeRecord{
+		"null-status": {created: "2024-01-01T00:00:00Z"},
+		"published":   {created: "2024-01-01T00:00:00Z", status: &published},
+		"archived":    {created: "2024-01-01T00:00:00Z", status: &archived},
+		"recent":      {created: "2024-04-29T00:00:00Z"},
+	}
+	var patched []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			var items []map[string]string
+			for id, rec := range records {
+				if matchFilter(r.URL.Query(), *rec) {
+					items = append(items, map[string]string{"id": id})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": items})
+		case http.MethodPatch:
+			var body struct {
+				Keys []string          `json:"keys"`
+				Data map[string]string `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			for _, id := range body.Keys {
+				status := body.Data["status"]
+				records[id].status = &status
+				patched = append(patched, id)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	worker := &RetentionWorker{
+		tenant: "timken",
+		cms: &DirectusClient{
+			baseURL:    server.URL,
+			apiKey:     "test-key",
+			httpClient: http.DefaultClient,
+		},
+		rules: []RetentionRule{{Type: "email_logs", Collection: "email_log", MaxAge: 30 * 24 * time.Hour, Archive: true}},
+		now:   func() time.Time { return time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC) },
+	}
+
+	if purged, err := worker.PurgeOnce(context.Background()); err != nil || purged["email_logs"] != 2 {
+		t.Errorf("first pass purged = %d (error %v), want 2", purged["email_logs"], err)
+	}
+	sort.Strings(patched)
+	if strings.Join(patched, ",") != "null-status,published" {
+		t.Errorf("archived = %v, want [null-status published]", patched)
+	}
+	if records["recent"].status != nil {
+		t.Error("recent record should not be archived")
+	}
+
+	// Archived records no longer match, so a second pass is a no-op
+	if purged, err := worker.PurgeOnce(context.Background()); err != nil || purged["email_logs"] != 0 {
+		t.Errorf("second pass purged = %d (error %v), want 0", purged["email_logs"], err)
+	}
+}
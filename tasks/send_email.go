@@ -1,9 +1,12 @@
 package tasks
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"mime"
+	"mime/quotedprintable"
 	"net/mail"
 	"net/smtp"
 	"strings"
@@ -11,17 +14,10 @@ import (
 	"go.uber.org/zap"
 
 	"tv-pipelines-timken/configs"
+	"tv-pipelines-timken/i18n"
 	"tv-pipelines-timken/types"
 )
 
-const (
-	emailSubject = "Timken Certificate of Conformance"
-	emailBody    = `Please find the attached certificate of conformance for your Timken products.
-
-Kind regards,
-Timken support team.`
-)
-
 // SendEmail sends the COC email with the PDF attachment. Returns true if email was sent.
 func SendEmail(ctx context.Context, cfg *configs.Config, cocData *types.COCData, pdfData []byte, pdfFilename string) (bool, error) {
 	logger := zap.L().With(zap.String("task", "send_email"))
@@ -53,15 +49,27 @@ func SendEmail(ctx context.Context, cfg *configs.Config, cocData *types.COCData,
 		}
 	}
 
+	subject, body := emailContent(cfg)
+
 	// Send the email
-	if err := sendEmailWithAttachment(cfg, recipients, emailSubject, emailBody, pdfFilename, pdfData); err != nil {
+	if err := sendEmailWithAttachment(cfg, recipients, subject, body, pdfFilename, pdfData); err != nil {
 		return false, fmt.Errorf("send email: %w", err)
 	}
 
-	logger.Info("send_email complete", zap.Int("recipient_count", len(recipients)))
+	logger.Info("send_email complete",
+		zap.Int("recipient_count", len(recipients)),
+		zap.String("locale", cfg.EmailLocale))
 	return true, nil
 }
 
+// emailContent returns the subject and body of the COC email in the tenant's
+// email language
+func emailContent(cfg *configs.Config) (subject, body string) {
+	subject = i18n.T(cfg.EmailLocale, "email.subject", cfg.DisplayName)
+	body = i18n.T(cfg.EmailLocale, "email.body", cfg.DisplayName)
+	return subject, body
+}
+
 func collectEmailAddresses(shipTo, soldTo []string) []string {
 	seen := make(map[string]bool)
 	var result []string
@@ -78,12 +86,35 @@ func collectEmailAddresses(shipTo, soldTo []string) []string {
 }
 
 func sendEmailWithAttachment(cfg *configs.Config, to []string, subject, body, attachmentName string, attachmentData []byte) error {
+	msg, err := buildEmailMessage(cfg.EmailFromAddress, to, subject, body, attachmentName, attachmentData)
+	if err != nil {
+		return err
+	}
+
+	auth := smtp.PlainAuth("", cfg.EmailSMTPUser, cfg.EmailSMTPPassword, cfg.EmailSMTPHost)
+	addr := fmt.Sprintf("%s:%s", cfg.EmailSMTPHost, cfg.EmailSMTPPort)
+
+	return smtp.SendMail(addr, auth, cfg.EmailFromAddress, to, msg)
+}
+
+// buildEmailMessage builds a MIME message with a PDF attachment. The subject
+// and body are encoded so translated (non-ASCII) text survives transport.
+func buildEmailMessage(from string, to []string, subject, body, attachmentName string, attachmentData []byte) ([]byte, error) {
 	boundary := "----=_Part_0_1234567890"
 
+	var encodedBody bytes.Buffer
+	qp := quotedprintable.NewWriter(&encodedBody)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("encode body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("encode body: %w", err)
+	}
+
 	var msg strings.Builder
-	msg.WriteString(fmt.Sprintf("From: %s\r\n", cfg.EmailFromAddress))
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", from))
 	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(to, ", ")))
-	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
 	msg.WriteString("MIME-Version: 1.0\r\n")
 	msg.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\r\n", boundary))
 	msg.WriteString("\r\n")
@@ -91,8 +122,9 @@ func sendEmailWithAttachment(cfg *configs.Config, to []string, subject, body, at
 	// Body part
 	msg.WriteString(fmt.Sprintf("--%s\r\n", boundary))
 	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
 	msg.WriteString("\r\n")
-	msg.WriteString(body)
+	msg.WriteString(encodedBody.String())
 	msg.WriteString("\r\n")
 
 	// Attachment part
@@ -107,8 +139,5 @@ func sendEmailWithAttachment(cfg *configs.Config, to []string, subject, body, at
 	// End boundary
 	msg.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
 
-	auth := smtp.PlainAuth("", cfg.EmailSMTPUser, cfg.EmailSMTPPassword, cfg.EmailSMTPHost)
-	addr := fmt.Sprintf("%s:%s", cfg.EmailSMTPHost, cfg.EmailSMTPPort)
-
-	return smtp.SendMail(addr, auth, cfg.EmailFromAddress, to, []byte(msg.String()))
+	return []byte(msg.String()), nil
 }
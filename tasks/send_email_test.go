@@ -1,12 +1,19 @@
 package tasks
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
 	"testing"
 
 	"go.uber.org/zap"
 
 	"tv-pipelines-timken/configs"
+	"tv-pipelines-timken/i18n"
 	"tv-pipelines-timken/types"
 )
 
@@ -129,3 +136,77 @@ func TestSendEmail_NilData(t *testing.T) {
 		t.Error("SendEmail() expected error for nil COC data")
 	}
 }
+
+func TestEmailContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *configs.Config
+		wantSubject string
+		wantBody    string
+	}{
+		{
+			name:        "default tenant",
+			cfg:         &configs.Config{TenantID: "timken", DisplayName: "Timken", EmailLocale: "en"},
+			wantSubject: "Timken Certificate of Conformance",
+			wantBody:    "Please find the attached certificate of conformance for your Timken products.\n\nKind regards,\nTimken support team.",
+		},
+		{
+			name:        "other tenant uses its own name and locale",
+			cfg:         &configs.Config{TenantID: "acme", DisplayName: "Acme Bearings", EmailLocale: "de"},
+			wantSubject: "Acme Bearings Konformitätsbescheinigung",
+			wantBody:    "Anbei erhalten Sie die Konformitätsbescheinigung für Ihre Acme Bearings-Produkte.\n\nMit freundlichen Grüßen\nIhr Acme Bearings Support-Team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, body := emailContent(tt.cfg)
+			if subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", subject, tt.wantSubject)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestBuildEmailMessage_Localized(t *testing.T) {
+	subject := i18n.T("de", "email.subject", "Timken")
+	body := i18n.T("de", "email.body", "Timken")
+
+	raw, err := buildEmailMessage("noreply@timken.com", []string{"a@example.com"}, subject, body, "COC-1.pdf", []byte("pdf"))
+	if err != nil {
+		t.Fatalf("buildEmailMessage() error = %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+
+	gotSubject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("DecodeHeader() error = %v", err)
+	}
+	if gotSubject != subject {
+		t.Errorf("Subject = %q, want %q", gotSubject, subject)
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType() error = %v", err)
+	}
+	part, err := multipart.NewReader(msg.Body, params["boundary"]).NextPart()
+	if err != nil {
+		t.Fatalf("NextPart() error = %v", err)
+	}
+	// multipart.Reader decodes quoted-printable transparently
+	gotBody, err := io.ReadAll(part)
+	if err != nil {
+		t.Fatalf("read body error = %v", err)
+	}
+	if got := strings.ReplaceAll(string(gotBody), "\r\n", "\n"); got != body {
+		t.Errorf("body = %q, want %q", got, body)
+	}
+}
@@ -50,10 +50,26 @@ type DirectusFileResponse struct {
 
 // PipelineRequest represents the incoming HTTP request
 type PipelineRequest struct {
+	Tenant    string   `json:"tenant,omitempty"` // optional; defaults to the API key's tenant
 	SSCC      string   `json:"sscc"`
 	SkipSteps []string `json:"skip_steps,omitempty"`
 }
 
+// Step statuses reported in StepStatus.Status
+const (
+	StepCompleted = "completed"
+	StepSkipped   = "skipped"
+	StepFailed    = "failed"
+	StepNotRun    = "not_run"
+)
+
+// StepStatus represents the outcome of a single pipeline step
+type StepStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "completed", "skipped", "failed", "not_run"
+	Error  string `json:"error,omitempty"`
+}
+
 // PipelineResult holds the outcome of a pipeline execution
 type PipelineResult struct {
 	Success         bool
@@ -61,13 +77,17 @@ type PipelineResult struct {
 	FileID          string
 	EmailSent       bool
 	Error           string
+	Warnings        []string     // non-fatal problems, e.g. email failed after upload
+	Steps           []StepStatus // per-step outcome in execution order
 }
 
 // PipelineResponse represents the HTTP response
 type PipelineResponse struct {
-	Success         bool   `json:"success"`
-	CertificationID string `json:"certification_id,omitempty"`
-	FileID          string `json:"file_id,omitempty"`
-	EmailSent       bool   `json:"email_sent"`
-	Error           string `json:"error,omitempty"`
+	Success         bool         `json:"success"`
+	CertificationID string       `json:"certification_id,omitempty"`
+	FileID          string       `json:"file_id,omitempty"`
+	EmailSent       bool         `json:"email_sent"`
+	Error           string       `json:"error,omitempty"`
+	Warnings        []string     `json:"warnings,omitempty"`
+	Steps           []StepStatus `json:"steps,omitempty"`
 }
@@ -102,4 +102,37 @@ func TestPipelineResponse_OmitEmpty(t *testing.T) {
 	if _, exists := result["certification_id"]; exists {
 		t.Error("certification_id should be omitted when empty")
 	}
+	if _, exists := result["warnings"]; exists {
+		t.Error("warnings should be omitted when empty")
+	}
+}
+
+func TestPipelineResponse_Warnings(t *testing.T) {
+	response := PipelineResponse{
+		Success:         true,
+		CertificationID: "cert-123",
+		FileID:          "file-456",
+		Warnings:        []string{"send_email failed: smtp timeout"},
+		Steps: []StepStatus{
+			{Name: "upload_pdf", Status: StepCompleted},
+			{Name: "send_email", Status: StepFailed, Error: "smtp timeout"},
+		},
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Marshal error = %v", err)
+	}
+
+	var result PipelineResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal error = %v", err)
+	}
+
+	if len(result.Warnings) != 1 {
+		t.Errorf("warnings count = %d, want 1", len(result.Warnings))
+	}
+	if len(result.Steps) != 2 || result.Steps[1].Status != StepFailed || result.Steps[1].Error != "smtp timeout" {
+		t.Errorf("steps = %+v, want send_email failed with error", result.Steps)
+	}
 }